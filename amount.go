@@ -0,0 +1,140 @@
+package match
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// AmountExtractor returns the currency and the amount of a money value. The
+// amount should be a number, *big.Rat or a RatConverter, so it can be matched
+// by the comparison patterns like Between.
+type AmountExtractor func(value interface{}) (currency string, amount interface{}, ok bool)
+
+var (
+	amountExtractorsMu sync.RWMutex
+	amountExtractors   []AmountExtractor
+	currencyFields     = []string{"Currency", "CurrencyCode"}
+	amountFields       = []string{"Amount", "Value", "Units"}
+)
+
+// RegisterAmountExtractor registers extractor of money types which are not
+// supported by Amount out of the box. Registered extractors are tried first.
+func RegisterAmountExtractor(extractor AmountExtractor) {
+	amountExtractorsMu.Lock()
+	defer amountExtractorsMu.Unlock()
+
+	amountExtractors = append(amountExtractors, extractor)
+}
+
+type amountPattern struct {
+	currency string
+	value    interface{}
+}
+
+// Amount defines the pattern for money values in the currency whose amount
+// matches the value pattern. The currency is compared case-insensitively.
+// Out of the box it supports structs with a Currency or CurrencyCode string
+// field and an Amount, Value or Units field (with Nanos like google.type.Money),
+// and maps with the same keys in lower camel or snake case.
+func Amount(currency string, valuePattern interface{}) interface{} {
+	return amountPattern{currency, valuePattern}
+}
+
+func (ap amountPattern) formatPattern() string {
+	return "Amount(" + strconv.Quote(ap.currency) + ", " + FormatPattern(ap.value) + ")"
+}
+
+func (ap amountPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	currency, amount, ok := extractAmount(value)
+	if !ok || !strings.EqualFold(currency, ap.currency) {
+		return nil, false
+	}
+
+	return matchValue(ms, ap.value, amount)
+}
+
+func extractAmount(value interface{}) (string, interface{}, bool) {
+	amountExtractorsMu.RLock()
+	extractors := amountExtractors
+	amountExtractorsMu.RUnlock()
+
+	for _, extractor := range extractors {
+		if currency, amount, ok := extractor(value); ok {
+			return currency, amount, true
+		}
+	}
+
+	if entries, ok := value.(map[string]interface{}); ok {
+		return mapAmount(entries)
+	}
+
+	structValue, ok := structValueOf(value)
+	if !ok {
+		return "", nil, false
+	}
+
+	return structAmount(structValue)
+}
+
+func mapAmount(entries map[string]interface{}) (string, interface{}, bool) {
+	currency, ok := "", false
+	for _, key := range []string{"currency", "currencyCode", "currency_code"} {
+		if currency, ok = entries[key].(string); ok {
+			break
+		}
+	}
+
+	if !ok {
+		return "", nil, false
+	}
+
+	for _, key := range []string{"amount", "value", "units"} {
+		if amount, ok := entries[key]; ok {
+			return currency, amount, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func structAmount(structValue reflect.Value) (string, interface{}, bool) {
+	currencyField, ok := exportedField(structValue, currencyFields)
+	if !ok || currencyField.Kind() != reflect.String {
+		return "", nil, false
+	}
+
+	amountField, ok := exportedField(structValue, amountFields)
+	if !ok {
+		return "", nil, false
+	}
+
+	amount := amountField.Interface()
+	// google.type.Money keeps the fractional part in nano units
+	if nanos, ok := exportedField(structValue, []string{"Nanos"}); ok && isIntKind(nanos.Kind()) && isIntKind(amountField.Kind()) {
+		units := new(big.Rat).SetInt64(amountField.Int())
+		amount = units.Add(units, big.NewRat(nanos.Int(), 1e9))
+	}
+
+	return currencyField.String(), amount, true
+}
+
+func exportedField(structValue reflect.Value, names []string) (reflect.Value, bool) {
+	for _, name := range names {
+		field, ok := structValue.Type().FieldByName(name)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		fieldValue, err := structValue.FieldByIndexErr(field.Index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		return fieldValue, true
+	}
+
+	return reflect.Value{}, false
+}
@@ -0,0 +1,81 @@
+package match
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMoney struct {
+	Currency string
+	Amount   float64
+}
+
+type testProtoMoney struct {
+	CurrencyCode string
+	Units        int64
+	Nanos        int32
+}
+
+type testCents struct {
+	code  string
+	cents int64
+}
+
+func TestAmount_Structs(t *testing.T) {
+	route := func(val interface{}) interface{} {
+		_, res := Match(val).
+			When(Amount("EUR", Gt(1000)), "review").
+			When(Amount("eur", ANY), "eur").
+			When(Amount("USD", Between(0, 10.5)), "small usd").
+			When(ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "review", route(testMoney{"EUR", 1500}))
+	assert.Equal(t, "eur", route(&testMoney{"EUR", 15}))
+	assert.Equal(t, "small usd", route(testProtoMoney{"USD", 10, 500000000}))
+	assert.Equal(t, "other", route(testProtoMoney{"USD", 10, 500000001}))
+	assert.Equal(t, "other", route(42))
+}
+
+func TestAmount_Map(t *testing.T) {
+	isMatched, _ := Match(map[string]interface{}{"currency_code": "GBP", "amount": 5.0}).
+		When(Amount("GBP", 5.0), true).
+		Result()
+
+	assert.True(t, isMatched)
+	assert.Equal(t, `Amount("GBP", 5)`, FormatPattern(Amount("GBP", 5)))
+}
+
+func TestAmount_RegisterExtractorConcurrently(t *testing.T) {
+	defer func(extractors []AmountExtractor) { amountExtractors = extractors }(amountExtractors)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterAmountExtractor(func(value interface{}) (string, interface{}, bool) { return "", nil, false })
+		}()
+		go func() {
+			defer wg.Done()
+			isMatched, _ := Match(testMoney{"EUR", 10}).When(Amount("EUR", 10.0), true).Result()
+			assert.True(t, isMatched)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAmount_RegisteredExtractor(t *testing.T) {
+	defer func(extractors []AmountExtractor) { amountExtractors = extractors }(amountExtractors)
+	RegisterAmountExtractor(func(value interface{}) (string, interface{}, bool) {
+		cents, ok := value.(testCents)
+		return cents.code, float64(cents.cents) / 100, ok
+	})
+
+	isMatched, _ := Match(testCents{"JPY", 1999}).When(Amount("JPY", Between(19, 20)), true).Result()
+	assert.True(t, isMatched)
+}
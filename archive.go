@@ -0,0 +1,182 @@
+package match
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"strconv"
+)
+
+// maxArchiveFileSize is the size limit of the archived files whose content is
+// matched, larger files don't match content patterns.
+var maxArchiveFileSize int64 = 32 << 20
+
+type archivePattern struct {
+	format  string
+	glob    string
+	content interface{}
+}
+
+// ZipContains defines the pattern for zip archives with a file whose name
+// matches the glob, see Glob, and whose content as []byte matches the
+// pattern. Archives can be []byte, *os.File or io.ReaderAt with a Size
+// method like *bytes.Reader. The content is read only when the pattern isn't
+// ANY, files larger than 32 MiB don't match other patterns.
+func ZipContains(nameGlob string, contentPattern interface{}) interface{} {
+	return archivePattern{"Zip", nameGlob, contentPattern}
+}
+
+// TarContains defines the pattern for tar archives, optionally gzip
+// compressed, with a regular file like ZipContains does. Other readers than
+// the ones ZipContains accepts are read once, so they can be matched by a
+// single branch only.
+func TarContains(nameGlob string, contentPattern interface{}) interface{} {
+	return archivePattern{"Tar", nameGlob, contentPattern}
+}
+
+func (ap archivePattern) formatPattern() string {
+	return ap.format + "Contains(" + strconv.Quote(ap.glob) + ", " + FormatPattern(ap.content) + ")"
+}
+
+func (ap archivePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if _, err := path.Match(ap.glob, ""); err != nil {
+		panic(&PatternError{Pattern: ap, Err: err})
+	}
+
+	if ap.format == "Zip" {
+		return ap.matchZip(ms, value)
+	}
+
+	return ap.matchTar(ms, value)
+}
+
+func (ap archivePattern) matchZip(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	r, size, ok := readerAtOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, false
+	}
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		if matched, _ := path.Match(ap.glob, file.Name); !matched {
+			continue
+		}
+
+		if ap.content == ANY {
+			return nil, true
+		}
+
+		content, err := file.Open()
+		if err != nil {
+			continue
+		}
+
+		data, ok := readArchiveFile(content)
+		content.Close()
+		if !ok {
+			continue
+		}
+
+		if matchedItems, matched := matchValue(ms, ap.content, data); matched {
+			return matchedItems, true
+		}
+	}
+
+	return nil, false
+}
+
+func (ap archivePattern) matchTar(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	var r io.Reader
+	if ra, size, ok := readerAtOf(value); ok {
+		r = io.NewSectionReader(ra, 0, size)
+	} else if reader, ok := value.(io.Reader); ok {
+		r = reader
+	} else {
+		return nil, false
+	}
+
+	buffered := bufio.NewReader(r)
+	if head, _ := buffered.Peek(2); bytes.Equal(head, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, false
+		}
+		defer gz.Close()
+
+		r = gz
+	} else {
+		r = buffered
+	}
+
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if err != nil {
+			return nil, false
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if matched, _ := path.Match(ap.glob, header.Name); !matched {
+			continue
+		}
+
+		if ap.content == ANY {
+			return nil, true
+		}
+
+		data, ok := readArchiveFile(archive)
+		if !ok {
+			continue
+		}
+
+		if matchedItems, matched := matchValue(ms, ap.content, data); matched {
+			return matchedItems, true
+		}
+	}
+}
+
+// readArchiveFile reads the content of the archived file up to maxArchiveFileSize.
+func readArchiveFile(r io.Reader) ([]byte, bool) {
+	data, err := io.ReadAll(io.LimitReader(r, maxArchiveFileSize+1))
+	return data, err == nil && int64(len(data)) <= maxArchiveFileSize
+}
+
+// readerAtOf returns the archive value as io.ReaderAt with its size.
+func readerAtOf(value interface{}) (io.ReaderAt, int64, bool) {
+	if data, ok := bytesOf(value); ok {
+		return bytes.NewReader(data), int64(len(data)), true
+	}
+
+	switch r := value.(type) {
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil {
+			return nil, 0, false
+		}
+
+		return r, info.Size(), true
+	case interface {
+		io.ReaderAt
+		Size() int64
+	}:
+		return r, r.Size(), true
+	}
+
+	return nil, 0, false
+}
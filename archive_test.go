@@ -0,0 +1,95 @@
+package match
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testArchiveFiles = map[string]string{
+	"bin/tool":    "\x7fELF binary",
+	"LICENSE":     "MIT License",
+	"docs/README": "usage",
+}
+
+func testZip(t *testing.T) []byte {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range testArchiveFiles {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		f.Write([]byte(content))
+	}
+	assert.NoError(t, w.Close())
+
+	return b.Bytes()
+}
+
+func testTarGz(t *testing.T) []byte {
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	w := tar.NewWriter(gz)
+	for name, content := range testArchiveFiles {
+		assert.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		w.Write([]byte(content))
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, gz.Close())
+
+	return b.Bytes()
+}
+
+func TestZipContains(t *testing.T) {
+	archive := testZip(t)
+
+	isMatched, _ := Match(archive).When(ZipContains("bin/*", Magic("ELF")), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(bytes.NewReader(archive)).When(ZipContains("LICENSE", HasPrefix("MIT")), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(archive).When(ZipContains("*.md", ANY), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match([]byte("not a zip")).When(ZipContains("*", ANY), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestTarContains(t *testing.T) {
+	archive := testTarGz(t)
+
+	_, res := Match(archive).
+		When(TarContains("LICENSE", HasPrefix("Apache")), "apache").
+		When(TarContains("LICENSE", HasPrefix("MIT")), "mit").
+		Result()
+	assert.Equal(t, "mit", res)
+
+	isMatched, _ := Match(bytes.NewBuffer(archive)).When(TarContains("docs/*", ANY), true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestArchiveContains_SizeLimit(t *testing.T) {
+	defer func(saved int64) { maxArchiveFileSize = saved }(maxArchiveFileSize)
+	maxArchiveFileSize = int64(len("usage"))
+
+	for _, archive := range [][]byte{testZip(t), testTarGz(t)} {
+		_, res := Match(archive).
+			When(ZipContains("LICENSE", HasPrefix("MIT")), "zip license").
+			When(TarContains("LICENSE", HasPrefix("MIT")), "tar license").
+			When(ZipContains("docs/*", HasPrefix("usage")), "zip docs").
+			When(TarContains("docs/*", HasPrefix("usage")), "tar docs").
+			Result()
+		assert.Contains(t, []string{"zip docs", "tar docs"}, res)
+	}
+}
+
+func TestArchiveContains_InvalidGlob(t *testing.T) {
+	pattern := ZipContains("[", ANY)
+	_, _, err := Match(testZip(t)).When(pattern, true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
@@ -0,0 +1,145 @@
+package match
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MatchArgs function returns the Matcher for command line arguments, like
+// os.Args[1:], which are matched with slice patterns of subcommand literals,
+// Flag, FlagValue and Arg items, e.g.
+// []interface{}{"deploy", Flag("f", "force"), Arg[int](), TAIL}.
+func MatchArgs(args []string) *Matcher {
+	return Match(args)
+}
+
+// Flag defines the pattern for the argument which is the flag with any of
+// the names, like "-v" or "--verbose".
+func Flag(names ...string) interface{} {
+	return funcPattern{"Flag(" + formatStrings(names) + ")", func(value interface{}) bool {
+		name, ok := flagName(value)
+		if !ok {
+			return false
+		}
+
+		for _, n := range names {
+			if name == n {
+				return true
+			}
+		}
+
+		return false
+	}}
+}
+
+type flagValuePattern struct {
+	name    string
+	pattern interface{}
+}
+
+// FlagValue defines the pattern for the argument "--name=value" (or
+// "-name=value") with the value matching the pattern. The action gets the
+// value bound by the pattern, like the parsed value of Arg, or the value string.
+func FlagValue(name string, pattern interface{}) interface{} {
+	return flagValuePattern{name, pattern}
+}
+
+func (fp flagValuePattern) formatPattern() string {
+	return "FlagValue(" + strconv.Quote(fp.name) + ", " + FormatPattern(fp.pattern) + ")"
+}
+
+func (fp flagValuePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	flag, ok := flagName(value)
+	if !ok {
+		return nil, false
+	}
+
+	name, flagValue, ok := strings.Cut(flag, "=")
+	if !ok || name != fp.name {
+		return nil, false
+	}
+
+	matchedItems, matched := matchValue(ms, fp.pattern, flagValue)
+	if !matched {
+		return nil, false
+	}
+
+	if isBindingPattern(fp.pattern) && len(matchedItems) > 0 {
+		return matchedItems, true
+	}
+
+	return []MatchItem{{value: flagValue}}, true
+}
+
+type argPattern[T argType] struct{}
+
+type argType interface {
+	int | int64 | uint | float64 | bool | string | time.Duration
+}
+
+// Arg defines the pattern for the argument which can be parsed as T. The
+// action gets the parsed value.
+func Arg[T argType]() interface{} {
+	return argPattern[T]{}
+}
+
+func (argPattern[T]) formatPattern() string {
+	var zero T
+	return fmt.Sprintf("Arg[%T]()", zero)
+}
+
+func (argPattern[T]) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	var parsed interface{}
+	var err error
+	var zero T
+	switch interface{}(zero).(type) {
+	case int:
+		parsed, err = strconv.Atoi(str)
+	case int64:
+		parsed, err = strconv.ParseInt(str, 10, 64)
+	case uint:
+		var u uint64
+		u, err = strconv.ParseUint(str, 10, 0)
+		parsed = uint(u)
+	case float64:
+		parsed, err = strconv.ParseFloat(str, 64)
+	case bool:
+		parsed, err = strconv.ParseBool(str)
+	case time.Duration:
+		parsed, err = time.ParseDuration(str)
+	case string:
+		if _, isFlag := flagName(str); isFlag {
+			return nil, false
+		}
+
+		parsed = str
+	}
+
+	if err != nil {
+		return nil, false
+	}
+
+	return []MatchItem{{value: parsed}}, true
+}
+
+// flagName returns the name of the flag argument, negative numbers like "-5"
+// aren't flags.
+func flagName(value interface{}) (string, bool) {
+	arg, ok := value.(string)
+	if !ok || len(arg) < 2 || arg[0] != '-' || arg == "--" {
+		return "", false
+	}
+
+	if _, err := strconv.ParseFloat(arg, 64); err == nil {
+		return "", false
+	}
+
+	return strings.TrimPrefix(arg[1:], "-"), true
+}
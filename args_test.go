@@ -0,0 +1,46 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchArgs(t *testing.T) {
+	run := func(args ...string) interface{} {
+		_, res := MatchArgs(args).
+			When([]interface{}{"version"}, "version").
+			When([]interface{}{"scale", Arg[string](), Arg[int]()}, func(name MatchItem, replicas MatchItem) []interface{} {
+				return []interface{}{name.Value(), replicas.Value()}
+			}).
+			When([]interface{}{"deploy", Flag("f", "force"), TAIL}, func(targets MatchItem) []interface{} {
+				return targets.Slice()
+			}).
+			When([]interface{}{"wait", FlagValue("timeout", Arg[time.Duration]())}, func(timeout MatchItem) interface{} {
+				return timeout.Value()
+			}).
+			When(ANY, "usage").
+			Result()
+
+		return res
+	}
+
+	assert.Equal(t, "version", run("version"))
+	assert.Equal(t, []interface{}{"web", 3}, run("scale", "web", "3"))
+	assert.Equal(t, "usage", run("scale", "web", "three"))
+	assert.Equal(t, []interface{}{"api", "worker"}, run("deploy", "--force", "api", "worker"))
+	assert.Equal(t, 30*time.Second, run("wait", "--timeout=30s"))
+	assert.Equal(t, "usage", run("wait", "--timeout=soon"))
+}
+
+func TestMatchArgs_NegativeNumbers(t *testing.T) {
+	_, res := MatchArgs([]string{"move", "-5", "-1.5"}).
+		When([]interface{}{"move", Flag("5"), TAIL}, "flag").
+		When([]interface{}{"move", Arg[int](), Arg[string]()}, func(steps MatchItem, speed MatchItem) []interface{} {
+			return []interface{}{steps.Value(), speed.Value()}
+		}).
+		Result()
+
+	assert.Equal(t, []interface{}{-5, "-1.5"}, res)
+}
@@ -0,0 +1,103 @@
+package match
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type claimPattern struct {
+	path    string
+	pattern interface{}
+}
+
+// Claim defines the pattern for decoded JWT claims, like map[string]interface{}
+// or jwt.MapClaims, having the claim which matches the pattern. Nested claims
+// are addressed by a dotted path like "realm_access.roles". For array claims
+// like aud any element can match, unless the pattern is a slice pattern,
+// EveryElem or SomeElem. Numbers are matched with numeric tolerance, since
+// JSON numbers are decoded as float64. Claims must be verified before.
+func Claim(path string, pattern interface{}) interface{} {
+	return claimPattern{path, pattern}
+}
+
+func (cp claimPattern) formatPattern() string {
+	return "Claim(" + strconv.Quote(cp.path) + ", " + FormatPattern(cp.pattern) + ")"
+}
+
+func (cp claimPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	claim := value
+	for _, name := range strings.Split(cp.path, ".") {
+		claims := reflect.ValueOf(claim)
+		if claims.Kind() != reflect.Map || claims.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+
+		item := claims.MapIndex(reflect.ValueOf(name).Convert(claims.Type().Key()))
+		if !item.IsValid() {
+			return nil, false
+		}
+
+		claim = item.Interface()
+	}
+
+	tolerant := *ms
+	tolerant.numericTolerance = true
+
+	if matchedItems, matched := matchValue(&tolerant, cp.pattern, claim); matched {
+		return matchedItems, true
+	}
+
+	if elements, ok := claim.([]interface{}); ok {
+		for _, element := range elements {
+			if matchedItems, matched := matchValue(&tolerant, cp.pattern, element); matched {
+				return matchedItems, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+type cookiePattern struct {
+	name    string
+	pattern interface{}
+}
+
+// Cookie defines the pattern for cookies with the name whose value matches
+// the pattern. It matches *http.Cookie and http.Cookie values, []*http.Cookie
+// containing the cookie and *http.Request with the cookie.
+func Cookie(name string, valuePattern interface{}) interface{} {
+	return cookiePattern{name, valuePattern}
+}
+
+func (cp cookiePattern) formatPattern() string {
+	return "Cookie(" + strconv.Quote(cp.name) + ", " + FormatPattern(cp.pattern) + ")"
+}
+
+func (cp cookiePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	var cookies []*http.Cookie
+	switch v := value.(type) {
+	case *http.Cookie:
+		cookies = []*http.Cookie{v}
+	case http.Cookie:
+		cookies = []*http.Cookie{&v}
+	case []*http.Cookie:
+		cookies = v
+	case *http.Request:
+		cookies = v.Cookies()
+	}
+
+	for _, cookie := range cookies {
+		if cookie == nil || cookie.Name != cp.name {
+			continue
+		}
+
+		if matchedItems, matched := matchValue(ms, cp.pattern, cookie.Value); matched {
+			return matchedItems, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,61 @@
+package match
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMapClaims map[string]interface{}
+
+func TestClaim(t *testing.T) {
+	claims := testMapClaims{
+		"sub":          "42",
+		"aud":          []interface{}{"api", "web"},
+		"exp":          float64(1700000000),
+		"role":         "ops",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"billing"}},
+	}
+
+	policy := func(claims interface{}) interface{} {
+		_, res := Match(claims).
+			When(Claim("role", OneOf("admin", "ops")), "staff").
+			When(Claim("realm_access.roles", "billing"), "billing").
+			When(ANY, "denied").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "staff", policy(claims))
+	assert.Equal(t, "billing", policy(map[string]interface{}{"realm_access": claims["realm_access"]}))
+	assert.Equal(t, "denied", policy(map[string]interface{}{"role": "user"}))
+	assert.Equal(t, "denied", policy("token"))
+
+	isMatched, _ := Match(claims).When(Claim("aud", "web"), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(map[string]interface{}{"exp": float64(10)}).When(Claim("exp", 10), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(claims).When(Claim("aud", EveryElem("api")), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+	isMatched, _ := Match(req).When(Cookie("theme", OneOf("dark", "light")), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(req).When(Cookie("missing", ANY), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(http.Cookie{Name: "a", Value: "1"}).When(Cookie("a", "1"), true).Result()
+	assert.True(t, isMatched)
+
+	assert.Equal(t, `Cookie("a", ANY)`, FormatPattern(Cookie("a", ANY)))
+}
@@ -0,0 +1,104 @@
+// Package benchmarks measures the cost of patterns in isolation, so the
+// expensive branches of a rule set can be found before they reach
+// production. match.MatcherStats records the costs in production.
+package benchmarks
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"time"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+)
+
+// Result is the average cost of an operation measured by Branches or Compile.
+type Result struct {
+	Name        string
+	NsPerOp     int64
+	AllocsPerOp uint64
+	BytesPerOp  uint64
+	// Matches is the number of values the pattern matched, it's 0 for Compile
+	Matches int
+}
+
+// Branches measures every pattern against the values, each one alone in a
+// RuleSet so the costs of the other branches aren't included. An operation
+// matches all the values and it's run runs times.
+func Branches(runs int, patterns []interface{}, values ...interface{}) []Result {
+	results := make([]Result, len(patterns))
+	for i, pattern := range patterns {
+		rs := match.NewRuleSet().When(pattern, true)
+		var matches int
+		for _, value := range values {
+			if isMatched, _ := rs.Result(value); isMatched {
+				matches++
+			}
+		}
+
+		results[i] = measure(runs, func() {
+			for _, value := range values {
+				rs.Result(value)
+			}
+		})
+		results[i].Name = fmt.Sprintf("branch %d %s", i, match.FormatPattern(pattern))
+		results[i].Matches = matches
+	}
+
+	return results
+}
+
+// Compile measures the construction of a pattern by build, e.g. compiling
+// of a regexp or a schema, it's run runs times.
+func Compile(runs int, name string, build func() interface{}) Result {
+	result := measure(runs, func() { build() })
+	result.Name = name
+
+	return result
+}
+
+// Report writes the results, the most expensive first.
+func Report(w io.Writer, results []Result) error {
+	sorted := append([]Result(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].NsPerOp > sorted[j].NsPerOp
+	})
+
+	for _, result := range sorted {
+		_, err := fmt.Fprintf(w, "%s: %d ns/op, %d allocs/op, %d B/op, %d matches\n",
+			result.Name, result.NsPerOp, result.AllocsPerOp, result.BytesPerOp, result.Matches)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// measure runs the op once to warm up and then runs times on a single
+// thread, like testing.AllocsPerRun, so allocations of other goroutines
+// aren't counted.
+func measure(runs int, op func()) Result {
+	if runs < 1 {
+		runs = 1
+	}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	op()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < runs; i++ {
+		op()
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return Result{
+		NsPerOp:     elapsed.Nanoseconds() / int64(runs),
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / uint64(runs),
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / uint64(runs),
+	}
+}
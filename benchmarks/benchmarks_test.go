@@ -0,0 +1,51 @@
+package benchmarks
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranches(t *testing.T) {
+	results := Branches(100, []interface{}{
+		"admin",
+		regexp.MustCompile("^adm"),
+		match.HasPrefix("x"),
+	}, "admin", "administrator", "user")
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, `branch 0 "admin"`, results[0].Name)
+	assert.Equal(t, 1, results[0].Matches)
+	assert.Equal(t, "branch 1 /^adm/", results[1].Name)
+	assert.Equal(t, 2, results[1].Matches)
+	assert.Equal(t, 0, results[2].Matches)
+}
+
+func TestCompile(t *testing.T) {
+	result := Compile(10, "regexp", func() interface{} {
+		return regexp.MustCompile(`^(\w+)@(\w+)\.com$`)
+	})
+
+	assert.Equal(t, "regexp", result.Name)
+	assert.True(t, result.AllocsPerOp > 0)
+	assert.True(t, result.BytesPerOp > 0)
+}
+
+func TestReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := Report(&buf, []Result{
+		{Name: "cheap", NsPerOp: 10},
+		{Name: "expensive", NsPerOp: 1000, AllocsPerOp: 3, BytesPerOp: 96, Matches: 2},
+	})
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"expensive: 1000 ns/op, 3 allocs/op, 96 B/op, 2 matches",
+		"cheap: 10 ns/op, 0 allocs/op, 0 B/op, 0 matches",
+	}, lines)
+}
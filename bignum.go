@@ -0,0 +1,96 @@
+package match
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// bigPrecision is the precision of big.Float used to compare big.Float
+// values with the other numbers.
+const bigPrecision = 512
+
+// RatConverter is implemented by decimal types like shopspring/decimal.Decimal,
+// so they are compared by value with the other numbers by Between, Gt and
+// the other comparison patterns.
+type RatConverter interface {
+	Rat() *big.Rat
+}
+
+func isBigNumber(value interface{}) bool {
+	switch value.(type) {
+	case *big.Int, *big.Float, *big.Rat, RatConverter:
+		return true
+	}
+
+	return false
+}
+
+// compareBig compares numbers when at least one of them is *big.Int,
+// *big.Float, *big.Rat or RatConverter. The values are compared as big.Rat
+// exactly, or as big.Float when one of them is a float.
+func compareBig(a interface{}, b interface{}) (int, bool) {
+	if ar, ok := toBigRat(a); ok {
+		if br, ok := toBigRat(b); ok {
+			return ar.Cmp(br), true
+		}
+	}
+
+	af, ok := toBigFloat(a)
+	if !ok {
+		return 0, false
+	}
+
+	bf, ok := toBigFloat(b)
+	if !ok {
+		return 0, false
+	}
+
+	return af.Cmp(bf), true
+}
+
+func toBigRat(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(v), true
+	case *big.Rat:
+		return v, v != nil
+	case RatConverter:
+		r := v.Rat()
+		return r, r != nil
+	}
+
+	val := reflect.ValueOf(value)
+	switch {
+	case isIntKind(val.Kind()):
+		return new(big.Rat).SetInt64(val.Int()), true
+	case isUintKind(val.Kind()):
+		return new(big.Rat).SetUint64(val.Uint()), true
+	}
+
+	return nil, false
+}
+
+func toBigFloat(value interface{}) (*big.Float, bool) {
+	if v, ok := value.(*big.Float); ok {
+		return v, v != nil
+	}
+
+	if r, ok := toBigRat(value); ok {
+		return new(big.Float).SetPrec(bigPrecision).SetRat(r), true
+	}
+
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64 {
+		if math.IsNaN(val.Float()) {
+			return nil, false
+		}
+
+		return new(big.Float).SetPrec(bigPrecision).SetFloat64(val.Float()), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,89 @@
+package match
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDecimal struct {
+	unscaled int64
+	scale    int64
+}
+
+func (d testDecimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(d.unscaled), new(big.Int).Exp(big.NewInt(10), big.NewInt(d.scale), nil))
+}
+
+func TestMatch_BigNumbersByValue(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	same, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	isMatched, _ := Match(same).When(huge, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(42).When(big.NewInt(42), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(big.NewRat(1, 2)).When(big.NewFloat(0.5), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(big.NewRat(1, 3)).When(big.NewRat(1, 2), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match("42").When(big.NewInt(42), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestMatch_BigNumberValues(t *testing.T) {
+	isMatched, _ := Match(big.NewInt(42)).When(42, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(big.NewInt(42)).When(43, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(big.NewFloat(0.5)).When(0.5, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(testDecimal{unscaled: 150, scale: 2}).When(1.5, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(big.NewInt(42)).When("42", true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(big.NewInt(42)).When(func(v *big.Int) bool { return v.IsInt64() }, true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestMatch_BigNumbersComparePatterns(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	_, res := Match(huge).
+		When(Lt(0), "negative").
+		When(Between(0, 1000), "small").
+		When(Gt(big.NewFloat(1e20)), "huge").
+		Result()
+	assert.Equal(t, "huge", res)
+
+	isMatched, _ := Match(big.NewRat(3, 2)).When(Between(1, 2.5), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(7).When(Gte(big.NewInt(7)), true).Result()
+	assert.True(t, isMatched)
+
+	assert.Equal(t, "Gt(1)", FormatPattern(Gt(1)))
+}
+
+func TestMatch_RatConverter(t *testing.T) {
+	price := testDecimal{unscaled: 1999, scale: 2}
+
+	_, res := Match(price).
+		When(Lte(10), "cheap").
+		When(Between(10, 50), "regular").
+		Result()
+	assert.Equal(t, "regular", res)
+
+	isMatched, _ := Match(price).When(big.NewRat(1999, 100), true).Result()
+	assert.True(t, isMatched)
+}
@@ -0,0 +1,63 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type bitsPattern struct {
+	offset, width int
+	pattern       interface{}
+}
+
+// Bits defines the pattern for the bit range of the width at the offset
+// matching the pattern, the range is decoded as uint64. Offsets of integers
+// count from the least significant bit, e.g. Bits(4, 4, 0xA) matches 0xA5.
+// Offsets of []byte count from the most significant bit of the first byte,
+// in the order the bits are drawn in protocol headers, e.g. Bits(0, 4, 4)
+// matches the version of an IPv4 header. Ranges beyond the value don't match.
+func Bits(offset int, width int, pattern interface{}) interface{} {
+	return bitsPattern{offset, width, pattern}
+}
+
+func (bp bitsPattern) formatPattern() string {
+	return fmt.Sprintf("Bits(%d, %d, %s)", bp.offset, bp.width, FormatPattern(bp.pattern))
+}
+
+func (bp bitsPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if bp.offset < 0 || bp.width < 1 || bp.width > 64 {
+		panic(newPatternError(bp, "the width has to be within 1-64 and the offset not negative"))
+	}
+
+	bits, ok := bp.extract(value)
+	if !ok {
+		return nil, false
+	}
+
+	tolerant := *ms
+	tolerant.numericTolerance = true
+
+	return matchValue(&tolerant, bp.pattern, bits)
+}
+
+func (bp bitsPattern) extract(value interface{}) (uint64, bool) {
+	if data, ok := value.([]byte); ok {
+		if bp.offset+bp.width > len(data)*8 {
+			return 0, false
+		}
+
+		var bits uint64
+		for i := bp.offset; i < bp.offset+bp.width; i++ {
+			bits = bits<<1 | uint64(data[i/8]>>(7-i%8)&1)
+		}
+
+		return bits, true
+	}
+
+	n, ok := toBits(value)
+	if !ok || bp.offset+bp.width > reflect.TypeOf(value).Bits() {
+		return 0, false
+	}
+
+	return n >> bp.offset & (1<<bp.width - 1), true
+}
@@ -0,0 +1,45 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits_Integer(t *testing.T) {
+	isMatched, _ := Match(0xA5).When(Bits(4, 4, 0xA), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(uint8(0xA5)).When(Bits(0, 4, Between(4, 6)), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(uint8(0xA5)).When(Bits(6, 4, ANY), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(^uint64(0)).When(Bits(0, 64, ^uint64(0)), true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestBits_Bytes(t *testing.T) {
+	ipv4Header := []byte{0x45, 0x00, 0x00, 0x54}
+
+	_, res := Match(ipv4Header).
+		When(Bits(0, 4, 6), "v6").
+		When(Bits(0, 4, 4), "v4").
+		Result()
+	assert.Equal(t, "v4", res)
+
+	isMatched, _ := Match(ipv4Header).When(Bits(4, 4, 5), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(ipv4Header).When(Bits(20, 12, 0x054), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(ipv4Header).When(Bits(30, 4, ANY), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestBits_Invalid(t *testing.T) {
+	_, _, err := Match(1).When(Bits(0, 0, ANY), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+}
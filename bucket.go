@@ -0,0 +1,37 @@
+package match
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Bucketize function returns the Matcher for the index of the bucket the
+// number falls in, so actions are added per bucket:
+//
+//	_, label := match.Bucketize(latencyMs, []float64{10, 100, 1000}).
+//		When(0, "fast").
+//		When(match.OneOf(1, 2), "ok").
+//		When(3, "slow").
+//		Result()
+//
+// Like the Prometheus histogram buckets the upper bounds are inclusive:
+// the index is of the first boundary greater than or equal to the number, or
+// len(boundaries) when the number is greater than all of them. The boundaries
+// are sorted, values which aren't numbers get the index -1.
+func Bucketize(value interface{}, boundaries []float64) *Matcher {
+	return Match(bucketIndex(value, boundaries))
+}
+
+func bucketIndex(value interface{}, boundaries []float64) int {
+	f, ok := toFloat(reflect.ValueOf(value))
+	if !ok {
+		return -1
+	}
+
+	if !sort.Float64sAreSorted(boundaries) {
+		boundaries = append([]float64(nil), boundaries...)
+		sort.Float64s(boundaries)
+	}
+
+	return sort.SearchFloat64s(boundaries, f)
+}
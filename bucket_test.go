@@ -0,0 +1,34 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketize(t *testing.T) {
+	label := func(latency interface{}) interface{} {
+		_, res := Bucketize(latency, []float64{10, 100, 1000}).
+			When(0, "fast").
+			When(OneOf(1, 2), "ok").
+			When(3, "slow").
+			When(-1, "invalid").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "fast", label(3))
+	assert.Equal(t, "fast", label(10.0))
+	assert.Equal(t, "ok", label(uint8(11)))
+	assert.Equal(t, "ok", label(int64(1000)))
+	assert.Equal(t, "slow", label(1000.5))
+	assert.Equal(t, "invalid", label("10ms"))
+}
+
+func TestBucketize_UnsortedBoundaries(t *testing.T) {
+	boundaries := []float64{100, 10}
+	_, res := Bucketize(50, boundaries).When(1, "middle").Result()
+
+	assert.Equal(t, "middle", res)
+	assert.Equal(t, []float64{100, 10}, boundaries)
+}
@@ -0,0 +1,112 @@
+package match
+
+import (
+	"container/list"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"sync"
+)
+
+type cacheKey struct {
+	branch int
+	value  interface{}
+}
+
+type cacheEntry struct {
+	key          cacheKey
+	matchedItems []MatchItem
+	matched      bool
+}
+
+// lruCache keeps results of branch evaluations for the most recently used values.
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[cacheKey]*list.Element
+	recent  *list.List
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:    size,
+		entries: make(map[cacheKey]*list.Element, size),
+		recent:  list.New(),
+	}
+}
+
+func (c *lruCache) get(key cacheKey) ([]MatchItem, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, false
+	}
+
+	c.recent.MoveToFront(elem)
+	entry := elem.Value.(*cacheEntry)
+
+	return append([]MatchItem(nil), entry.matchedItems...), entry.matched, true
+}
+
+func (c *lruCache) put(key cacheKey, matchedItems []MatchItem, matched bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.recent.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.recent.PushFront(&cacheEntry{key, append([]MatchItem(nil), matchedItems...), matched})
+	if c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.recent.Len()
+}
+
+// isCacheable reports whether the value can be a cache key, NaN isn't equal
+// to itself so values containing it would never be found.
+func isCacheable(val interface{}) bool {
+	if val == nil {
+		return false
+	}
+
+	value := reflect.ValueOf(val)
+
+	return value.Comparable() && !hasNaN(value)
+}
+
+func hasNaN(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return cmplx.IsNaN(value.Complex())
+	case reflect.Interface:
+		return !value.IsNil() && hasNaN(value.Elem())
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if hasNaN(value.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if hasNaN(value.Field(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package match
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// CommandResult is the outcome of a command run by RunAndMatch. Its fields
+// are matched by StructOf or Fields patterns, e.g.
+//
+//	match.Fields{"ExitCode": match.Between(1, 2), "Stderr": regexp.MustCompile("timeout")}
+type CommandResult struct {
+	// ExitCode is -1 when the process was terminated by a signal.
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Duration time.Duration
+	// State is the state of the exited process.
+	State *os.ProcessState
+}
+
+// RunAndMatch runs the command and returns the Matcher for its
+// CommandResult. The output is captured while still written to the Stdout
+// and Stderr of the command, if they are set. A non-zero exit code isn't an
+// error, the error is returned when the command fails to start or its output
+// can't be copied.
+func RunAndMatch(cmd *exec.Cmd) (*Matcher, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = teeWriter(&stdout, cmd.Stdout)
+	cmd.Stderr = teeWriter(&stderr, cmd.Stderr)
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+
+	return Match(CommandResult{
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Duration: duration,
+		State:    cmd.ProcessState,
+	}), nil
+}
+
+func teeWriter(capture io.Writer, w io.Writer) io.Writer {
+	if w == nil {
+		return capture
+	}
+
+	return io.MultiWriter(capture, w)
+}
@@ -0,0 +1,38 @@
+package match
+
+import (
+	"bytes"
+	"os/exec"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunAndMatch(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("sh", "-c", "echo built; echo 'disk full' >&2; exit 3")
+	cmd.Stdout = &stdout
+
+	matcher, err := RunAndMatch(cmd)
+	assert.NoError(t, err)
+
+	_, res := matcher.
+		When(Fields{"ExitCode": 0}, "ok").
+		When(Fields{"ExitCode": Between(1, 5), "Stderr": regexp.MustCompile("disk full"), "Duration": Lt(time.Minute)}, "retry").
+		When(ANY, "fail").
+		Result()
+
+	assert.Equal(t, "retry", res)
+	assert.Equal(t, "built\n", stdout.String())
+}
+
+func TestRunAndMatch_NotFound(t *testing.T) {
+	_, err := RunAndMatch(exec.Command("this-command-does-not-exist"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package match
+
+import (
+	"fmt"
+	"math/cmplx"
+)
+
+// ComplexApprox defines the pattern for complex64 and complex128 values
+// within the distance epsilon from c.
+func ComplexApprox(c complex128, epsilon float64) interface{} {
+	return funcPattern{fmt.Sprintf("ComplexApprox(%v, %v)", c, epsilon), func(value interface{}) bool {
+		v, ok := toComplex(value)
+		return ok && cmplx.Abs(v-c) <= epsilon
+	}}
+}
+
+// MagnitudeBetween defines the pattern for complex values whose magnitude is in the range [min, max].
+func MagnitudeBetween(min float64, max float64) interface{} {
+	return funcPattern{fmt.Sprintf("MagnitudeBetween(%v, %v)", min, max), func(value interface{}) bool {
+		v, ok := toComplex(value)
+		return ok && cmplx.Abs(v) >= min && cmplx.Abs(v) <= max
+	}}
+}
+
+// PhaseBetween defines the pattern for complex values whose phase is in the
+// range [min, max]. The phase is in radians in the range [-Pi, Pi].
+func PhaseBetween(min float64, max float64) interface{} {
+	return funcPattern{fmt.Sprintf("PhaseBetween(%v, %v)", min, max), func(value interface{}) bool {
+		v, ok := toComplex(value)
+		return ok && cmplx.Phase(v) >= min && cmplx.Phase(v) <= max
+	}}
+}
+
+func toComplex(value interface{}) (complex128, bool) {
+	switch v := value.(type) {
+	case complex128:
+		return v, true
+	case complex64:
+		return complex128(v), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,35 @@
+package match
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplexApprox(t *testing.T) {
+	isMatched, _ := Match(complex(1.0000001, 2)).When(ComplexApprox(1+2i, 1e-6), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(complex64(1+2i)).When(ComplexApprox(1+2i, 1e-6), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(1.0).When(ComplexApprox(1, 1e-6), true).Result()
+	assert.False(t, isMatched)
+
+	assert.Equal(t, "ComplexApprox((1+2i), 1e-06)", FormatPattern(ComplexApprox(1+2i, 1e-6)))
+}
+
+func TestMagnitudeAndPhaseBetween(t *testing.T) {
+	_, res := Match(3+4i).
+		When(MagnitudeBetween(0, 1), "small").
+		When(MagnitudeBetween(4, 6), "medium").
+		Result()
+	assert.Equal(t, "medium", res)
+
+	_, res = Match(-1i).
+		When(PhaseBetween(0, math.Pi), "upper").
+		When(PhaseBetween(-math.Pi, 0), "lower").
+		Result()
+	assert.Equal(t, "lower", res)
+}
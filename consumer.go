@@ -0,0 +1,91 @@
+package match
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnroutable is passed to the dead-letter callback of the Consumer when no
+// route matches the message.
+var ErrUnroutable = errors.New("match: no route matches the message")
+
+// Consumer classifies JSON messages of a queue and routes them to typed
+// handlers. Consume has the func([]byte) error signature used by most queue
+// clients.
+type Consumer struct {
+	rules      *RuleSet
+	deadLetter func(msg []byte, reason error)
+}
+
+// NewConsumer creates a Consumer without routes.
+func NewConsumer() *Consumer {
+	return &Consumer{rules: NewRuleSet().WithNumericTolerance()}
+}
+
+// Route adds the route for messages matching the pattern. The pattern is
+// matched against the generic JSON value of the message (map[string]interface{}
+// for objects) with numeric tolerance, then the message is decoded into T for
+// the handler. Routes are checked in the order they were added.
+func Route[T any](c *Consumer, pattern interface{}, handler func(msg T) error) *Consumer {
+	c.rules.When(pattern, func() interface{} {
+		return func(raw []byte) error {
+			var msg T
+			if err := json.Unmarshal(raw, &msg); err != nil {
+				return err
+			}
+
+			return handler(msg)
+		}
+	})
+
+	return c
+}
+
+// DeadLetter sets the callback for messages which are invalid JSON, don't
+// match any route or can't be decoded for their handler.
+func (c *Consumer) DeadLetter(callback func(msg []byte, reason error)) *Consumer {
+	c.deadLetter = callback
+
+	return c
+}
+
+// Consume routes the message and returns the error of its handler. Messages
+// passed to the dead-letter callback are consumed without error, without the
+// callback the reason is returned.
+func (c *Consumer) Consume(msg []byte) error {
+	var generic interface{}
+	if err := json.Unmarshal(msg, &generic); err != nil {
+		return c.toDeadLetter(msg, err)
+	}
+
+	_, route, err := c.rules.ResultE(generic)
+	if errors.Is(err, ErrNoMatch) {
+		return c.toDeadLetter(msg, fmt.Errorf("%w: %v", ErrUnroutable, err))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := route.(func([]byte) error)(msg); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return c.toDeadLetter(msg, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *Consumer) toDeadLetter(msg []byte, reason error) error {
+	if c.deadLetter == nil {
+		return reason
+	}
+
+	c.deadLetter(msg, reason)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package match
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderCreated struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+type userDeleted struct {
+	UserID int `json:"user_id"`
+}
+
+func TestConsumer_Routes(t *testing.T) {
+	var orders []orderCreated
+	var users []userDeleted
+
+	c := NewConsumer()
+	Route(c, map[string]interface{}{"type": "order.created", "version": 2}, func(msg orderCreated) error {
+		orders = append(orders, msg)
+		return nil
+	})
+	Route(c, map[string]interface{}{"type": "user.deleted"}, func(msg userDeleted) error {
+		users = append(users, msg)
+		return nil
+	})
+
+	assert.NoError(t, c.Consume([]byte(`{"type":"order.created","version":2,"id":"a1","total":30}`)))
+	assert.NoError(t, c.Consume([]byte(`{"type":"user.deleted","user_id":7}`)))
+
+	assert.Equal(t, []orderCreated{{"a1", 30}}, orders)
+	assert.Equal(t, []userDeleted{{7}}, users)
+}
+
+func TestConsumer_DeadLetter(t *testing.T) {
+	var reasons []error
+	c := NewConsumer().DeadLetter(func(msg []byte, reason error) {
+		reasons = append(reasons, reason)
+	})
+	Route(c, map[string]interface{}{"type": "user.deleted"}, func(msg userDeleted) error { return nil })
+
+	assert.NoError(t, c.Consume([]byte(`{"type":"unknown"}`)))
+	assert.NoError(t, c.Consume([]byte(`not json`)))
+	assert.NoError(t, c.Consume([]byte(`{"type":"user.deleted","user_id":"seven"}`)))
+
+	assert.Len(t, reasons, 3)
+	assert.True(t, errors.Is(reasons[0], ErrUnroutable))
+}
+
+func TestConsumer_HandlerError(t *testing.T) {
+	errHandler := errors.New("db down")
+	c := NewConsumer()
+	Route(c, ANY, func(msg map[string]interface{}) error { return errHandler })
+
+	assert.Equal(t, errHandler, c.Consume([]byte(`{}`)))
+}
+
+func TestConsumer_UnroutableWithoutDeadLetter(t *testing.T) {
+	err := NewConsumer().Consume([]byte(`{}`))
+
+	assert.True(t, errors.Is(err, ErrUnroutable))
+}
@@ -0,0 +1,113 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type sliceOfPattern[T any] struct {
+	elem interface{}
+}
+
+// SliceOf defines the pattern for slices and arrays of T whose every element
+// matches the pattern. A []T is matched without reflection, as well as the
+// elements when the pattern is a func(T) bool. An empty slice is matched.
+func SliceOf[T any](elemPattern interface{}) interface{} {
+	return sliceOfPattern[T]{elemPattern}
+}
+
+func (sp sliceOfPattern[T]) formatPattern() string {
+	return fmt.Sprintf("SliceOf[%s](%s)", typeOf[T](), FormatPattern(sp.elem))
+}
+
+func (sp sliceOfPattern[T]) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if values, ok := value.([]T); ok {
+		for _, v := range values {
+			if !matchTyped(ms, sp.elem, v) {
+				return nil, false
+			}
+		}
+
+		return nil, true
+	}
+
+	valueSlice, ok := sliceValueOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	for i := 0; i < valueSlice.Len(); i++ {
+		v, ok := valueSlice.Index(i).Interface().(T)
+		if !ok || !matchTyped(ms, sp.elem, v) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+type mapOfPattern[K comparable, V any] struct {
+	key   interface{}
+	value interface{}
+}
+
+// MapOf defines the pattern for maps from K to V whose every entry matches
+// the key and value patterns. A map[K]V is matched without reflection, as
+// well as the entries when the patterns are func(K) bool and func(V) bool.
+// An empty map is matched, values implementing Iterable are matched too.
+func MapOf[K comparable, V any](keyPattern interface{}, valPattern interface{}) interface{} {
+	return mapOfPattern[K, V]{keyPattern, valPattern}
+}
+
+func (mp mapOfPattern[K, V]) formatPattern() string {
+	return fmt.Sprintf("MapOf[%s, %s](%s, %s)", typeOf[K](), typeOf[V](), FormatPattern(mp.key), FormatPattern(mp.value))
+}
+
+func (mp mapOfPattern[K, V]) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if entries, ok := value.(map[K]V); ok {
+		for k, v := range entries {
+			if !matchTyped(ms, mp.key, k) || !matchTyped(ms, mp.value, v) {
+				return nil, false
+			}
+		}
+
+		return nil, true
+	}
+
+	if iterable, ok := value.(Iterable); ok {
+		value = iterableMap(iterable)
+	}
+
+	valueMap := reflect.ValueOf(value)
+	if valueMap.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	iter := valueMap.MapRange()
+	for iter.Next() {
+		k, keyOk := iter.Key().Interface().(K)
+		v, valueOk := iter.Value().Interface().(V)
+		if !keyOk || !valueOk || !matchTyped(ms, mp.key, k) || !matchTyped(ms, mp.value, v) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+// matchTyped matches the value of the known type, a func(T) bool pattern is called directly.
+func matchTyped[T any](ms *matchState, pattern interface{}, value T) bool {
+	if pattern == ANY {
+		return true
+	}
+
+	if check, ok := pattern.(func(T) bool); ok {
+		return check(value)
+	}
+
+	return matchValueBool(ms, pattern, value)
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,47 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceOf(t *testing.T) {
+	positive := SliceOf[int](func(i int) bool { return i > 0 })
+
+	isMatched, _ := Match([]int{1, 2, 3}).When(positive, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]int{1, -2}).When(positive, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match([]interface{}{1, 2}).When(positive, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]interface{}{1, "2"}).When(positive, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match([]string{"a", "b"}).When(SliceOf[string](OneOf("a", "b")), true).Result()
+	assert.True(t, isMatched)
+
+	assert.Equal(t, "SliceOf[int](ANY)", FormatPattern(SliceOf[int](ANY)))
+}
+
+func TestMapOf(t *testing.T) {
+	pattern := MapOf[string, int](HasPrefix("x-"), func(v int) bool { return v < 10 })
+
+	isMatched, _ := Match(map[string]int{"x-a": 1, "x-b": 9}).When(pattern, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(map[string]int{"x-a": 1, "b": 2}).When(pattern, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(map[string]interface{}{"x-a": 1}).When(pattern, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(map[string]int{}).When(pattern, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]int{1}).When(pattern, true).Result()
+	assert.False(t, isMatched)
+}
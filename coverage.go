@@ -0,0 +1,121 @@
+package match
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	coverageEnabled atomic.Bool
+	coverageMu      sync.Mutex
+	coverageSites   = map[string][]*branchCoverage{}
+	// packageDir is used to skip the frames of the package when looking for
+	// the construction site, so Match called by MatchRow or Dispatcher
+	// is recorded at the caller of those.
+	packageDir = func() string {
+		_, file, _, _ := runtime.Caller(0)
+		return filepath.Dir(file)
+	}()
+)
+
+type branchCoverage struct {
+	pattern string
+	hits    uint64
+}
+
+// EnableCoverage starts recording which branches are matched per Matcher
+// and RuleSet construction site. It's meant for tests, usually it's called in
+// TestMain and CoverageReport is written after the tests are run to find dead
+// or shadowed branches. Only matchers constructed after the call are recorded.
+func EnableCoverage() {
+	coverageEnabled.Store(true)
+}
+
+// ResetCoverage stops recording and drops the recorded coverage.
+func ResetCoverage() {
+	coverageEnabled.Store(false)
+
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	coverageSites = map[string][]*branchCoverage{}
+}
+
+// CoverageReport writes the branches which were never matched, grouped by
+// the construction site of their Matcher or RuleSet.
+func CoverageReport(w io.Writer) error {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	sites := make([]string, 0, len(coverageSites))
+	for site := range coverageSites {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+
+	var total, unhit int
+	var b strings.Builder
+	for _, site := range sites {
+		for i, branch := range coverageSites[site] {
+			total++
+			if branch.hits > 0 {
+				continue
+			}
+
+			unhit++
+			fmt.Fprintf(&b, "%s: branch %d %s never matched\n", site, i, truncate(branch.pattern))
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "match coverage: %d of %d branches never matched\n%s", unhit, total, b.String())
+	return err
+}
+
+// coverageSite returns the construction site of a matcher, or empty string
+// when the coverage isn't enabled.
+func coverageSite() string {
+	if !coverageEnabled.Load() {
+		return ""
+	}
+
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+func coverBranch(site string, index int, pattern interface{}) {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	// the same site is usually constructed many times, the branches are
+	// identified by their index
+	if index < len(coverageSites[site]) {
+		return
+	}
+
+	coverageSites[site] = append(coverageSites[site], &branchCoverage{pattern: FormatPattern(pattern)})
+}
+
+func coverHit(site string, index int) {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	if branches := coverageSites[site]; index < len(branches) {
+		branches[index].hits++
+	}
+}
@@ -0,0 +1,56 @@
+package match
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func matchCovered(val int) (bool, interface{}) {
+	return Match(val).
+		When(1, "one").
+		When(ANY, "any").
+		When(2, "two").
+		Result()
+}
+
+func TestCoverage_ReportsNeverMatchedBranches(t *testing.T) {
+	EnableCoverage()
+	defer ResetCoverage()
+
+	matchCovered(1)
+	matchCovered(2)
+
+	var buf bytes.Buffer
+	assert.NoError(t, CoverageReport(&buf))
+
+	report := buf.String()
+	assert.Contains(t, report, "match coverage: 1 of 3 branches never matched")
+	assert.Contains(t, report, "coverage_test.go")
+	assert.Contains(t, report, "branch 2 2 never matched")
+}
+
+func TestCoverage_RuleSet(t *testing.T) {
+	EnableCoverage()
+	defer ResetCoverage()
+
+	rs := NewRuleSet().
+		When(1, "one").
+		When(2, "two")
+
+	rs.Result(2)
+
+	var buf bytes.Buffer
+	assert.NoError(t, CoverageReport(&buf))
+	assert.Contains(t, buf.String(), "match coverage: 1 of 2 branches never matched")
+	assert.Contains(t, buf.String(), "branch 0 1 never matched")
+}
+
+func TestCoverage_DisabledByDefault(t *testing.T) {
+	Match(1).When(2, true).Result()
+
+	var buf bytes.Buffer
+	assert.NoError(t, CoverageReport(&buf))
+	assert.Equal(t, "match coverage: 0 of 0 branches never matched\n", buf.String())
+}
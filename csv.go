@@ -0,0 +1,42 @@
+package match
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MatchCSVRecord function takes a record read by encoding/csv and returns the
+// Matcher for it. Columns are matched with slice patterns, e.g.
+// []interface{}{"order", Between(1, 100), regexp.MustCompile("^EU"), TAIL}.
+// Fields which are numbers, like "42" or " 4.2", also match number patterns
+// (Between, 42, 4.2), fields are matched as strings first. Only plain decimal
+// numbers count: "NaN", "Inf" and hex floats like "0x1p4" stay strings.
+func MatchCSVRecord(record []string) *Matcher {
+	matcher := Match(record).WithNumericTolerance()
+	matcher.state.numericStrings = true
+
+	return matcher
+}
+
+func parseNumericString(ms *matchState, value interface{}) (interface{}, bool) {
+	str, ok := value.(string)
+	if !ok || !ms.numericStrings {
+		return nil, false
+	}
+
+	str = strings.TrimSpace(str)
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return i, true
+	}
+
+	if strings.ContainsAny(str, "xX") {
+		return nil, false
+	}
+
+	if f, err := strconv.ParseFloat(str, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f, true
+	}
+
+	return nil, false
+}
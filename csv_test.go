@@ -0,0 +1,61 @@
+package match
+
+import (
+	"encoding/csv"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchCSVRecord(t *testing.T) {
+	reader := csv.NewReader(strings.NewReader("order,17,EU-west,express\nrefund,-3,US\n"))
+	reader.FieldsPerRecord = -1
+
+	var kinds []interface{}
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			break
+		}
+
+		_, kind := MatchCSVRecord(record).
+			When([]interface{}{"order", Between(1, 100), regexp.MustCompile("^EU"), TAIL}, "eu order").
+			When([]interface{}{"refund", Between(-10, -1), ANY}, "small refund").
+			Result()
+		kinds = append(kinds, kind)
+	}
+
+	assert.Equal(t, []interface{}{"eu order", "small refund"}, kinds)
+}
+
+func TestMatchCSVRecordNumbers(t *testing.T) {
+	_, res := MatchCSVRecord([]string{"007", " 2.50"}).
+		When([]interface{}{"7", ANY}, "string seven").
+		When([]interface{}{7, 2.5}, "numbers").
+		Result()
+
+	assert.Equal(t, "numbers", res)
+}
+
+func TestMatchCSVRecordStringsFirst(t *testing.T) {
+	_, res := MatchCSVRecord([]string{"007"}).
+		When([]interface{}{"007"}, "string").
+		When([]interface{}{7}, "number").
+		Result()
+
+	assert.Equal(t, "string", res)
+}
+
+func TestMatchCSVRecordNotDecimal(t *testing.T) {
+	_, res := MatchCSVRecord([]string{"0x10", "Inf", "-infinity", "NaN"}).
+		When([]interface{}{16, TAIL}, "hex").
+		When([]interface{}{ANY, math.Inf(1), TAIL}, "inf").
+		When([]interface{}{ANY, ANY, math.Inf(-1), TAIL}, "-inf").
+		When([]interface{}{"0x10", "Inf", "-infinity", "NaN"}, "strings").
+		Result()
+
+	assert.Equal(t, "strings", res)
+}
@@ -0,0 +1,80 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+)
+
+// Dispatcher runs the handlers subscribed to topics and payloads matching
+// their patterns. It is safe for concurrent use.
+type Dispatcher struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+type subscription struct {
+	topic   interface{}
+	payload interface{}
+	handler func(topic string, payload interface{})
+}
+
+// NewDispatcher creates a Dispatcher without subscriptions.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Subscribe adds the handler for topics matching the glob (see Glob) and
+// payloads matching the pattern. It panics with *PatternError when the glob
+// is malformed.
+func (d *Dispatcher) Subscribe(topicGlob string, payloadPattern interface{}, handler func(topic string, payload interface{})) *Dispatcher {
+	topic := Glob(topicGlob)
+	if _, err := path.Match(topicGlob, ""); err != nil {
+		panic(&PatternError{Pattern: topic, Err: err})
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.subscriptions = append(d.subscriptions, subscription{topic, payloadPattern, handler})
+
+	return d
+}
+
+// Publish runs all matching handlers concurrently and waits for them. It
+// returns the number of run handlers. A panic of a handler doesn't affect
+// the others, panics are returned joined in the error.
+func (d *Dispatcher) Publish(topic string, payload interface{}) (int, error) {
+	// the patterns are matched outside of the lock, so a panicking pattern
+	// doesn't leave it held
+	d.mu.RLock()
+	subscriptions := d.subscriptions
+	d.mu.RUnlock()
+
+	var handlers []func(topic string, payload interface{})
+	for _, sub := range subscriptions {
+		if matchValueBool(&matchState{}, sub.topic, topic) && matchValueBool(&matchState{}, sub.payload, payload) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(handlers))
+	for i, handler := range handlers {
+		wg.Add(1)
+		go func(i int, handler func(topic string, payload interface{})) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("match: handler for topic %q panicked: %v", topic, r)
+				}
+			}()
+
+			handler(topic, payload)
+		}(i, handler)
+	}
+	wg.Wait()
+
+	return len(handlers), errors.Join(errs...)
+}
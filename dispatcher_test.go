@@ -0,0 +1,80 @@
+package match
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderEvent struct {
+	Total int
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) func(string, interface{}) {
+		return func(string, interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name)
+		}
+	}
+
+	d := NewDispatcher().
+		Subscribe("orders/*", ANY, record("audit")).
+		Subscribe("orders/created", Fields{"Total": Between(1000, 1e9)}, record("big order")).
+		Subscribe("users/*", ANY, record("users"))
+
+	n, err := d.Publish("orders/created", orderEvent{5000})
+
+	sort.Strings(calls)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"audit", "big order"}, calls)
+}
+
+func TestDispatcher_PanicIsolation(t *testing.T) {
+	var called bool
+	d := NewDispatcher().
+		Subscribe("jobs/*", ANY, func(string, interface{}) { panic("boom") }).
+		Subscribe("jobs/*", ANY, func(string, interface{}) { called = true })
+
+	n, err := d.Publish("jobs/cleanup", nil)
+
+	assert.Equal(t, 2, n)
+	assert.ErrorContains(t, err, "boom")
+	assert.True(t, called)
+}
+
+func TestDispatcher_NoSubscribers(t *testing.T) {
+	n, err := NewDispatcher().
+		Subscribe("a/*", ANY, func(string, interface{}) {}).
+		Publish("b/c", 1)
+
+	assert.Equal(t, 0, n)
+	assert.NoError(t, err)
+}
+
+func TestDispatcher_PatternPanicReleasesLock(t *testing.T) {
+	d := NewDispatcher().Subscribe("jobs/*", Glob("["), func(string, interface{}) {})
+
+	assert.Panics(t, func() { d.Publish("jobs/cleanup", "payload") })
+
+	d.Subscribe("other/*", ANY, func(string, interface{}) {})
+	n, err := d.Publish("other/x", nil)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, err)
+}
+
+func TestDispatcher_SubscribeInvalidGlob(t *testing.T) {
+	defer func() {
+		err, ok := recover().(*PatternError)
+		assert.True(t, ok)
+		assert.Equal(t, `Glob("jobs/[")`, FormatPattern(err.Pattern))
+	}()
+
+	NewDispatcher().Subscribe("jobs/[", ANY, func(string, interface{}) {})
+}
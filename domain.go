@@ -0,0 +1,155 @@
+package match
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Domain defines the pattern for host names equal to the domain, where a "*"
+// label matches exactly one label like in TLS certificates, e.g.
+// Domain("*.example.com") matches "api.example.com" but neither "example.com"
+// nor "a.b.example.com". Both sides are compared case-insensitively, without
+// a trailing dot and with internationalized labels in the punycode form, so
+// Domain("bücher.example") matches "xn--bcher-kva.example". The UTS #46
+// mapping of full IDNA processing isn't applied.
+func Domain(domain string) interface{} {
+	labels := strings.Split(normalizeDomain(domain), ".")
+	return funcPattern{"Domain(" + strconv.Quote(domain) + ")", func(value interface{}) bool {
+		host, ok := value.(string)
+		if !ok || host == "" {
+			return false
+		}
+
+		hostLabels := strings.Split(normalizeDomain(host), ".")
+		if len(hostLabels) != len(labels) {
+			return false
+		}
+
+		for i, label := range labels {
+			if label != "*" && label != hostLabels[i] {
+				return false
+			}
+
+			if hostLabels[i] == "" {
+				return false
+			}
+		}
+
+		return true
+	}}
+}
+
+func normalizeDomain(domain string) string {
+	labels := strings.Split(strings.TrimSuffix(strings.ToLower(domain), "."), ".")
+	for i, label := range labels {
+		if utf8.RuneCountInString(label) != len(label) {
+			labels[i] = "xn--" + punycode(label)
+		}
+	}
+
+	return strings.Join(labels, ".")
+}
+
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// punycode encodes the label by RFC 3492, without the "xn--" prefix.
+func punycode(label string) string {
+	input := []rune(label)
+
+	var output strings.Builder
+	for _, r := range input {
+		if r < punycodeInitialN {
+			output.WriteRune(r)
+		}
+	}
+
+	basic := output.Len()
+	handled := basic
+	if basic > 0 {
+		output.WriteByte('-')
+	}
+
+	n, delta, bias := rune(punycodeInitialN), 0, punycodeInitialBias
+	for handled < len(input) {
+		m := rune(utf8.MaxRune)
+		for _, r := range input {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+
+		delta += int(m-n) * (handled + 1)
+		n = m
+
+		for _, r := range input {
+			if r < n {
+				delta++
+			}
+
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := k - bias
+				if t < punycodeTMin {
+					t = punycodeTMin
+				} else if t > punycodeTMax {
+					t = punycodeTMax
+				}
+
+				if q < t {
+					break
+				}
+
+				output.WriteByte(punycodeDigit(t + (q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+
+			output.WriteByte(punycodeDigit(q))
+			bias = punycodeAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+
+		delta++
+		n++
+	}
+
+	return output.String()
+}
+
+func punycodeAdapt(delta int, numPoints int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
+
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+
+	return byte('0' + d - 26)
+}
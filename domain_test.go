@@ -0,0 +1,33 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomain(t *testing.T) {
+	route := func(host string) interface{} {
+		_, res := Match(host).
+			When(Domain("example.com"), "apex").
+			When(Domain("*.example.com"), "tenant").
+			When(Domain("bücher.example"), "shop").
+			When(ANY, "unknown").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "apex", route("Example.COM."))
+	assert.Equal(t, "tenant", route("acme.example.com"))
+	assert.Equal(t, "unknown", route("a.b.example.com"))
+	assert.Equal(t, "unknown", route(".example.com"))
+	assert.Equal(t, "shop", route("xn--bcher-kva.example"))
+	assert.Equal(t, "shop", route("BÜCHER.example"))
+	assert.Equal(t, "unknown", route("example.org"))
+}
+
+func TestPunycode(t *testing.T) {
+	assert.Equal(t, "mnchen-3ya", punycode("münchen"))
+	assert.Equal(t, "bcher-kva", punycode("bücher"))
+	assert.Equal(t, "wgv71a119e", punycode("日本語"))
+}
@@ -0,0 +1,72 @@
+package match
+
+import (
+	"bytes"
+	"encoding/base64"
+	"unicode/utf8"
+)
+
+var (
+	// IsGzip is the pattern for gzip compressed data.
+	IsGzip interface{} = headFuncPattern{funcPattern{"IsGzip", func(value interface{}) bool {
+		return hasMagic(value, []byte{0x1f, 0x8b})
+	}}, 2}
+	// IsZstd is the pattern for zstd compressed data.
+	IsZstd interface{} = headFuncPattern{funcPattern{"IsZstd", func(value interface{}) bool {
+		return hasMagic(value, []byte{0x28, 0xb5, 0x2f, 0xfd})
+	}}, 4}
+	// IsBase64 is the pattern for data in the standard or URL base64 encoding.
+	// Unpadded data has to be at least 16 characters long, as short words are
+	// valid base64 too. It's a loose check, longer words still match.
+	IsBase64 interface{} = funcPattern{"IsBase64", isBase64}
+	// IsUTF8 is the pattern for valid UTF-8 encoded data.
+	IsUTF8 interface{} = funcPattern{"IsUTF8", func(value interface{}) bool {
+		data, ok := bytesOf(value)
+		return ok && utf8.Valid(data)
+	}}
+)
+
+// bytesOf returns the data of []byte and string values.
+func bytesOf(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	}
+
+	return nil, false
+}
+
+func hasMagic(value interface{}, magic []byte) bool {
+	data, ok := bytesOf(value)
+	return ok && bytes.HasPrefix(data, magic)
+}
+
+// minUnpaddedBase64Len is the length of the shortest unpadded data IsBase64 matches.
+const minUnpaddedBase64Len = 16
+
+func isBase64(value interface{}) bool {
+	data, ok := bytesOf(value)
+	if !ok || len(data) == 0 {
+		return false
+	}
+
+	encodings := []*base64.Encoding{base64.StdEncoding, base64.URLEncoding}
+	if data[len(data)-1] != '=' {
+		if len(data) < minUnpaddedBase64Len {
+			return false
+		}
+
+		encodings = append(encodings, base64.RawStdEncoding, base64.RawURLEncoding)
+	}
+
+	for _, encoding := range encodings {
+		decoded := make([]byte, encoding.DecodedLen(len(data)))
+		if _, err := encoding.Decode(decoded, data); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
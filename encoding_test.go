@@ -0,0 +1,37 @@
+package match
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodingPatterns(t *testing.T) {
+	var gzipped bytes.Buffer
+	w := gzip.NewWriter(&gzipped)
+	w.Write([]byte("hello"))
+	w.Close()
+
+	classify := func(blob interface{}) interface{} {
+		_, res := Match(blob).
+			When(IsGzip, "gzip").
+			When(IsZstd, "zstd").
+			When(IsBase64, "base64").
+			When(IsUTF8, "text").
+			When(ANY, "binary").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "gzip", classify(gzipped.Bytes()))
+	assert.Equal(t, "zstd", classify([]byte{0x28, 0xb5, 0x2f, 0xfd, 0}))
+	assert.Equal(t, "base64", classify([]byte("aGVsbG8=")))
+	assert.Equal(t, "base64", classify("aGVsbG8sIHdvcmxkIQ"))
+	assert.Equal(t, "text", classify("aGVsbG8"))
+	assert.Equal(t, "text", classify("abcd"))
+	assert.Equal(t, "text", classify("hello, world"))
+	assert.Equal(t, "binary", classify([]byte{0xff, 0xfe, 0x00}))
+	assert.Equal(t, "binary", classify(42))
+}
@@ -0,0 +1,176 @@
+package match
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+)
+
+type uintPattern struct {
+	size    int
+	order   binary.ByteOrder
+	pattern interface{}
+}
+
+// U16BE defines the pattern for the big endian uint16 matching the pattern.
+// Inside a slice pattern matched against []byte it's decoded from the two
+// bytes at its position and the following elements continue after them, e.g.
+// []interface{}{byte(0x01), U16BE(ANY), TAIL}. It binds the decoded integer
+// for the action, followed by the values bound by its pattern. Outside of
+// slice patterns it matches []byte values of exactly the size. Integers are
+// compared to number patterns by value, so U16BE(0x0800) needs no uint16 literal.
+func U16BE(pattern interface{}) interface{} {
+	return uintPattern{2, binary.BigEndian, pattern}
+}
+
+// U16LE defines the pattern for the little endian uint16, see U16BE.
+func U16LE(pattern interface{}) interface{} {
+	return uintPattern{2, binary.LittleEndian, pattern}
+}
+
+// U32BE defines the pattern for the big endian uint32, see U16BE.
+func U32BE(pattern interface{}) interface{} {
+	return uintPattern{4, binary.BigEndian, pattern}
+}
+
+// U32LE defines the pattern for the little endian uint32, see U16BE.
+func U32LE(pattern interface{}) interface{} {
+	return uintPattern{4, binary.LittleEndian, pattern}
+}
+
+// U64BE defines the pattern for the big endian uint64, see U16BE.
+func U64BE(pattern interface{}) interface{} {
+	return uintPattern{8, binary.BigEndian, pattern}
+}
+
+// U64LE defines the pattern for the little endian uint64, see U16BE.
+func U64LE(pattern interface{}) interface{} {
+	return uintPattern{8, binary.LittleEndian, pattern}
+}
+
+func (up uintPattern) formatPattern() string {
+	suffix := "BE"
+	if up.order == binary.LittleEndian {
+		suffix = "LE"
+	}
+
+	return fmt.Sprintf("U%d%s(%s)", up.size*8, suffix, FormatPattern(up.pattern))
+}
+
+func (up uintPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	data, ok := value.([]byte)
+	if !ok || len(data) != up.size {
+		return nil, false
+	}
+
+	return up.matchBytes(ms, data)
+}
+
+// matchBytes decodes the integer at the start of the data, which has at least the size.
+func (up uintPattern) matchBytes(ms *matchState, data []byte) ([]MatchItem, bool) {
+	var decoded interface{}
+	if up.size == 8 {
+		decoded = up.order.Uint64(data)
+	} else {
+		decoded, _ = readUint(data, up.size, up.order)
+	}
+
+	tolerant := *ms
+	tolerant.numericTolerance = true
+
+	matchedItems, matched := matchValue(&tolerant, up.pattern, decoded)
+	if !matched {
+		return nil, false
+	}
+
+	return append([]MatchItem{{value: decoded}}, matchedItems...), true
+}
+
+func hasUintPattern(pattern reflect.Value) bool {
+	for i := 0; i < pattern.Len(); i++ {
+		if _, ok := pattern.Index(i).Interface().(uintPattern); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchBytesSlice matches the slice pattern containing uintPattern elements,
+// which consume several bytes each. Unlike other slices, the data has to
+// cover every element of the pattern, while the last element is repeated for
+// the rest of the data like in matchSubSlice. A leading HEAD binds the bytes
+// before the first offset the rest of the pattern matches at.
+func matchBytesSlice(ms *matchState, pattern reflect.Value, data []byte) ([]MatchItem, bool) {
+	if pattern.Index(0).Interface() != HEAD {
+		return matchBytesElements(ms, pattern, 0, data)
+	}
+
+	for start := 0; start <= len(data); start++ {
+		matchedItems, matched := tryAlternative(ms, func() ([]MatchItem, bool) {
+			return matchBytesElements(ms, pattern, 1, data[start:])
+		})
+		if matched {
+			head := MatchItem{valueAsSlice: sliceValueToSliceOfInterfaces(reflect.ValueOf(data[:start]))}
+			return append([]MatchItem{head}, matchedItems...), true
+		}
+	}
+
+	return nil, false
+}
+
+// matchBytesElements matches the data with the elements of the pattern from
+// the first one.
+func matchBytesElements(ms *matchState, pattern reflect.Value, first int, data []byte) ([]MatchItem, bool) {
+	var matchedItems []MatchItem
+	last := pattern.Len() - 1
+	for i, offset := first, 0; offset < len(data) || i <= last; i++ {
+		currPattern := pattern.Index(min(i, last)).Interface()
+		switch {
+		case currPattern == HEAD && i > 0:
+			panic(newPatternError(pattern.Interface(), "HEAD can only be in first position of a pattern."))
+		case currPattern == TAIL:
+			if i < last {
+				panic(newPatternError(pattern.Interface(), "TAIL must me in last position of the pattern."))
+			}
+
+			rest := reflect.ValueOf(data[offset:])
+			return append(matchedItems, MatchItem{valueAsSlice: sliceValueToSliceOfInterfaces(rest)}), true
+		}
+
+		up, isUint := currPattern.(uintPattern)
+		size := 1
+		if isUint {
+			size = up.size
+		}
+
+		if offset+size > len(data) {
+			return nil, false
+		}
+
+		switch {
+		case isUint:
+			currMatchedItems, matched := up.matchBytes(ms, data[offset:])
+			if !matched {
+				return nil, false
+			}
+
+			matchedItems = append(matchedItems, currMatchedItems...)
+		case currPattern == ANY:
+			matchedItems = append(matchedItems, MatchItem{value: data[offset]})
+		default:
+			currMatchedItems, matched := matchValue(ms, currPattern, data[offset])
+			if !matched {
+				return nil, false
+			}
+
+			if isBindingPattern(currPattern) {
+				matchedItems = append(matchedItems, currMatchedItems...)
+			}
+		}
+
+		offset += size
+	}
+
+	return matchedItems, true
+}
@@ -0,0 +1,58 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUintPatterns_InSlice(t *testing.T) {
+	ethernetTail := []byte{0x08, 0x00, 0x45, 0x00, 0x01, 0x02, 0x03, 0x04}
+
+	_, res := Match(ethernetTail).
+		When([]interface{}{U16BE(0x86DD), TAIL}, "ipv6").
+		When([]interface{}{U16BE(0x0800), byte(0x45), ANY, U32LE(ANY)}, func(etherType, tos, word MatchItem) []interface{} {
+			return []interface{}{etherType.Value(), tos.Value(), word.Value()}
+		}).
+		Result()
+
+	assert.Equal(t, []interface{}{uint16(0x0800), byte(0x00), uint32(0x04030201)}, res)
+}
+
+func TestUintPatterns_Repeat(t *testing.T) {
+	isMatched, _ := Match([]byte{0x00, 0x01, 0x00, 0x02}).When([]interface{}{U16BE(Between(1, 2))}, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]byte{0x00, 0x01, 0x00}).When([]interface{}{U16BE(ANY)}, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match([]byte{0x01}).When([]interface{}{byte(0x01), U16LE(ANY)}, true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestUintPatterns_Value(t *testing.T) {
+	_, res := Match([]byte{0x01, 0, 0, 0, 0, 0, 0, 0}).
+		When(U64BE(1), "be").
+		When(U64LE(1), "le").
+		Result()
+	assert.Equal(t, "le", res)
+
+	isMatched, _ := Match([]byte{0x01, 0x02, 0x03}).When(U16BE(ANY), true).Result()
+	assert.False(t, isMatched)
+
+	assert.Equal(t, "U32BE(Gt(5))", FormatPattern(U32BE(Gt(5))))
+}
+
+func TestUintPatterns_LeadingHead(t *testing.T) {
+	_, res := Match([]byte{0xff, 0xfe, 0x08, 0x00}).
+		When([]interface{}{HEAD, U16BE(0x0800)}, func(head, etherType MatchItem) []interface{} {
+			return []interface{}{head.Slice(), etherType.Value()}
+		}).
+		Result()
+
+	assert.Equal(t, []interface{}{[]interface{}{byte(0xff), byte(0xfe)}, uint16(0x0800)}, res)
+
+	assert.Panics(t, func() {
+		Match([]byte{0x08, 0x00, 0x01}).When([]interface{}{U16BE(ANY), HEAD}, true).Result()
+	})
+}
@@ -0,0 +1,104 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotExhaustive is returned by EnumMatcher.Result when some members of
+// the enum aren't matched by any branch and there is no Otherwise action.
+var ErrNotExhaustive = errors.New("match: not exhaustive")
+
+// Enum is the set of members of an enum type, e.g. the iota constants.
+type Enum[T comparable] struct {
+	members []T
+}
+
+// DefineEnum defines the enum of the members, its Match requires a branch
+// for every member:
+//
+//	var colors = match.DefineEnum(Red, Green, Blue)
+//
+//	res, err := colors.Match(c).
+//		When(Red, "stop").
+//		When(match.OneOf(Green, Blue), "go").
+//		Result()
+func DefineEnum[T comparable](members ...T) *Enum[T] {
+	return &Enum[T]{append([]T(nil), members...)}
+}
+
+// Members returns the members of the enum in the order they were defined.
+func (e *Enum[T]) Members() []T {
+	return append([]T(nil), e.members...)
+}
+
+// Match function takes a value of the enum type and returns the EnumMatcher.
+func (e *Enum[T]) Match(value T) *EnumMatcher[T] {
+	return &EnumMatcher[T]{enum: e, matcher: Match(value)}
+}
+
+// EnumMatcher is the Matcher which checks that its branches cover every
+// member of the enum.
+type EnumMatcher[T comparable] struct {
+	enum         *Enum[T]
+	matcher      *Matcher
+	otherwise    interface{}
+	hasOtherwise bool
+}
+
+// When function adds new branch, the pattern is usually a member or OneOf
+// members, though any pattern matching members counts for the coverage.
+func (em *EnumMatcher[T]) When(pattern interface{}, action interface{}) *EnumMatcher[T] {
+	em.matcher.When(pattern, action)
+
+	return em
+}
+
+// Otherwise sets the action for the members without a branch and for values
+// which aren't members of the enum, the branches aren't required to be
+// exhaustive then.
+func (em *EnumMatcher[T]) Otherwise(action interface{}) *EnumMatcher[T] {
+	em.otherwise, em.hasOtherwise = action, true
+
+	return em
+}
+
+// Result returns the result value of matching process. Without Otherwise
+// the error wraps ErrNotExhaustive when some members aren't matched by any
+// branch, regardless of the value, so missing branches are found by any
+// test reaching the match. A value which isn't a member returns
+// *NoMatchError, invalid patterns *PatternError.
+func (em *EnumMatcher[T]) Result() (interface{}, error) {
+	if !em.hasOtherwise {
+		if missing := em.missing(); len(missing) > 0 {
+			return nil, fmt.Errorf("%w: no branch for %s", ErrNotExhaustive, formatList(missing))
+		}
+	}
+
+	matched, res, err := em.matcher.ResultE()
+	if !matched && em.hasOtherwise && errors.Is(err, ErrNoMatch) {
+		return callAction(em.otherwise, nil), nil
+	}
+
+	return res, err
+}
+
+// missing returns the members which aren't matched by any branch.
+func (em *EnumMatcher[T]) missing() []interface{} {
+	var missing []interface{}
+	for _, member := range em.enum.members {
+		covered := false
+		for _, mi := range em.matcher.matchItems {
+			if matchValueBool(&em.matcher.state, mi.pattern, member) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			missing = append(missing, member)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,65 @@
+package match
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type color int
+
+const (
+	red color = iota
+	green
+	blue
+)
+
+var colors = DefineEnum(red, green, blue)
+
+func TestEnum_Exhaustive(t *testing.T) {
+	res, err := colors.Match(green).
+		When(red, "stop").
+		When(OneOf(green, blue), "go").
+		Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "go", res)
+}
+
+func TestEnum_NotExhaustive(t *testing.T) {
+	_, err := colors.Match(red).
+		When(red, "stop").
+		When(green, "go").
+		Result()
+
+	assert.True(t, errors.Is(err, ErrNotExhaustive))
+	assert.Contains(t, err.Error(), "no branch for match.color(2)")
+}
+
+func TestEnum_Otherwise(t *testing.T) {
+	res, err := colors.Match(blue).
+		When(red, "stop").
+		Otherwise("other").
+		Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "other", res)
+
+	res, err = colors.Match(color(42)).
+		When(red, "stop").
+		Otherwise("other").
+		Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "other", res)
+}
+
+func TestEnum_NotMember(t *testing.T) {
+	_, err := colors.Match(color(42)).
+		When(OneOf(red, green, blue), "known").
+		Result()
+
+	assert.True(t, errors.Is(err, ErrNoMatch))
+	assert.Equal(t, []color{red, green, blue}, colors.Members())
+}
@@ -0,0 +1,26 @@
+package match
+
+import (
+	"os"
+	"strings"
+)
+
+// MatchEnv function returns the Matcher for the environment variables of the
+// process as map[string]string, e.g.
+// MatchEnv().When(map[string]interface{}{"APP_ENV": Glob("prod*")}, ...).
+// Map patterns list only the variables which must be set.
+func MatchEnv() *Matcher {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+
+	return MatchConfig(env)
+}
+
+// MatchConfig function returns the Matcher for the key-value configuration.
+func MatchConfig(config map[string]string) *Matcher {
+	return Match(config)
+}
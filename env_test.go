@@ -0,0 +1,32 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchEnv(t *testing.T) {
+	t.Setenv("APP_ENV", "production-eu")
+	t.Setenv("APP_DEBUG", "1")
+
+	_, res := MatchEnv().
+		When(map[string]interface{}{"APP_ENV": Glob("prod*"), "APP_DEBUG": "0"}, "prod").
+		When(map[string]interface{}{"APP_ENV": Glob("prod*"), "APP_DEBUG": OneOf("1", "true")}, "prod debug").
+		When(ANY, "dev").
+		Result()
+
+	assert.Equal(t, "prod debug", res)
+}
+
+func TestMatchConfig(t *testing.T) {
+	config := map[string]string{"mode": "canary-3", "region": "us-east-1"}
+
+	_, res := MatchConfig(config).
+		When(map[string]interface{}{"mode": "stable"}, "stable").
+		When(map[string]interface{}{"mode": regexp.MustCompile(`^canary-\d+$`), "region": HasPrefix("us-")}, "us canary").
+		Result()
+
+	assert.Equal(t, "us canary", res)
+}
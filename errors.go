@@ -0,0 +1,90 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoMatch is the error of ResultE when no pattern matches the value,
+// the returned error is *NoMatchError which errors.Is ErrNoMatch.
+var ErrNoMatch = errors.New("match: no pattern matched")
+
+const (
+	maxReportedPatterns    = 10
+	maxFormattedPatternLen = 80
+)
+
+// NoMatchError describes the value which no pattern matched.
+type NoMatchError struct {
+	Value    interface{}
+	Patterns []interface{}
+}
+
+func newNoMatchError(value interface{}, matchItems []matchItem) *NoMatchError {
+	patterns := make([]interface{}, len(matchItems))
+	for i, mi := range matchItems {
+		patterns[i] = mi.pattern
+	}
+
+	return &NoMatchError{Value: value, Patterns: patterns}
+}
+
+// Error lists the formatted value and at most 10 of the tried patterns,
+// every one of them shortened to 80 characters.
+func (e *NoMatchError) Error() string {
+	var b strings.Builder
+	b.WriteString("match: no pattern matched value ")
+	b.WriteString(truncate(FormatPattern(e.Value)))
+	b.WriteString(", tried:")
+
+	if len(e.Patterns) == 0 {
+		b.WriteString(" no patterns")
+	}
+
+	for i, pattern := range e.Patterns {
+		if i == maxReportedPatterns {
+			fmt.Fprintf(&b, " (and %d more)", len(e.Patterns)-maxReportedPatterns)
+			break
+		}
+
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(" ")
+		b.WriteString(truncate(FormatPattern(pattern)))
+	}
+
+	return b.String()
+}
+
+// Is reports that the error is ErrNoMatch.
+func (e *NoMatchError) Is(target error) bool {
+	return target == ErrNoMatch
+}
+
+func truncate(str string) string {
+	if len(str) <= maxFormattedPatternLen {
+		return str
+	}
+
+	return str[:maxFormattedPatternLen-3] + "..."
+}
+
+// PatternError describes invalid usage of a pattern.
+type PatternError struct {
+	Pattern interface{}
+	Err     error
+}
+
+func newPatternError(pattern interface{}, msg string) *PatternError {
+	return &PatternError{Pattern: pattern, Err: errors.New(msg)}
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("match: invalid pattern %s: %v", truncate(FormatPattern(e.Pattern)), e.Err)
+}
+
+func (e *PatternError) Unwrap() error {
+	return e.Err
+}
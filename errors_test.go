@@ -0,0 +1,80 @@
+package match
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_ResultEReturnsSlicePatternError(t *testing.T) {
+	isMatched, _, err := Match([]int{1, 2, 3}).
+		When([]interface{}{1, HEAD, 3}, true).
+		ResultE()
+
+	var patternErr *PatternError
+	assert.False(t, isMatched)
+	assert.True(t, errors.As(err, &patternErr))
+	assert.Equal(t, "match: invalid pattern [1, HEAD, 3]: HEAD can only be in first position of a pattern.", err.Error())
+}
+
+func TestMatch_ResultEMatched(t *testing.T) {
+	isMatched, res, err := Match(42).
+		When(42, 84).
+		ResultE()
+
+	assert.True(t, isMatched)
+	assert.Equal(t, 84, res)
+	assert.NoError(t, err)
+}
+
+type validatedValue string
+
+var errEmptyPattern = errors.New("empty pattern")
+
+func TestMatch_RegisterPatternWithError(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	RegisterMatcherE(func(pattern interface{}, value interface{}) (bool, error) {
+		if _, ok := value.(validatedValue); !ok {
+			return false, nil
+		}
+
+		if pattern == "" {
+			return false, errEmptyPattern
+		}
+
+		return pattern == string(value.(validatedValue)), nil
+	})
+
+	mr := Match(validatedValue("go")).
+		When("", true)
+
+	_, _, err := mr.ResultE()
+	assert.True(t, errors.Is(err, errEmptyPattern))
+	assert.Panics(t, func() { mr.Result() })
+}
+
+func TestMatch_ResultENoMatch(t *testing.T) {
+	isMatched, _, err := Match([]int{1, 2}).
+		When([]interface{}{HEAD, 3}, true).
+		When(OneOf("a", "b"), true).
+		ResultE()
+
+	var noMatchErr *NoMatchError
+	assert.False(t, isMatched)
+	assert.True(t, errors.Is(err, ErrNoMatch))
+	assert.True(t, errors.As(err, &noMatchErr))
+	assert.Equal(t, `match: no pattern matched value [1, 2], tried: [HEAD, 3], OneOf("a", "b")`, err.Error())
+}
+
+func TestMatch_ResultENoMatchIsBounded(t *testing.T) {
+	mr := Match(strings.Repeat("x", 100))
+	for i := 0; i < 15; i++ {
+		mr.When(i, true)
+	}
+
+	_, _, err := mr.ResultE()
+
+	assert.Equal(t, `match: no pattern matched value "`+strings.Repeat("x", 76)+`..., tried: 0, 1, 2, 3, 4, 5, 6, 7, 8, 9 (and 5 more)`, err.Error())
+}
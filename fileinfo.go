@@ -0,0 +1,95 @@
+package match
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strconv"
+)
+
+type fileInfoPattern struct {
+	glob string
+	size interface{}
+	mode interface{}
+}
+
+// FileInfoOf defines the pattern for fs.FileInfo and fs.DirEntry values whose
+// base name matches the glob, see Glob, and whose size and fs.FileMode match
+// the patterns, e.g. FileInfoOf("*.go", Lt(1<<20), HasFlags(0o100)). The size
+// is compared to number patterns by value.
+func FileInfoOf(nameGlob string, sizePattern interface{}, modePattern interface{}) interface{} {
+	return fileInfoPattern{nameGlob, sizePattern, modePattern}
+}
+
+func (fp fileInfoPattern) formatPattern() string {
+	return "FileInfoOf(" + strconv.Quote(fp.glob) + ", " + FormatPattern(fp.size) + ", " + FormatPattern(fp.mode) + ")"
+}
+
+func (fp fileInfoPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	info, ok := value.(fs.FileInfo)
+	if entry, isEntry := value.(fs.DirEntry); isEntry {
+		var err error
+		info, err = entry.Info()
+		ok = err == nil
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	matched, err := path.Match(fp.glob, info.Name())
+	if err != nil {
+		panic(&PatternError{Pattern: fp, Err: err})
+	}
+
+	if !matched {
+		return nil, false
+	}
+
+	tolerant := *ms
+	tolerant.numericTolerance = true
+
+	sizeItems, matched := matchValue(&tolerant, fp.size, info.Size())
+	if !matched {
+		return nil, false
+	}
+
+	modeItems, matched := matchValue(ms, fp.mode, info.Mode())
+	if !matched {
+		return nil, false
+	}
+
+	return append(sizeItems, modeItems...), true
+}
+
+// MatchWalk walks the file system and classifies every file, but not
+// directories, by the rules matched against its fs.FileInfo. It returns the
+// result values of the matched files by their paths. The error is returned
+// when the walk fails or a pattern is invalid, see PatternError.
+func MatchWalk(fsys fs.FS, rules *RuleSet) (map[string]interface{}, error) {
+	results := map[string]interface{}{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		_, res, err := rules.ResultE(info)
+		if errors.Is(err, ErrNoMatch) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		results[filePath] = res
+		return nil
+	})
+
+	return results, err
+}
@@ -0,0 +1,48 @@
+package match
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"cmd/tool/main.go": {Data: []byte("package main"), Mode: 0o644},
+	"scripts/build.sh": {Data: []byte("#!/bin/sh"), Mode: 0o755},
+	"assets/logo.png":  {Data: make([]byte, 2048), Mode: 0o644},
+	"README":           {Data: []byte("readme"), Mode: 0o644},
+}
+
+func TestMatchWalk(t *testing.T) {
+	rules := NewRuleSet().
+		When(FileInfoOf("*.go", ANY, ANY), "source").
+		When(FileInfoOf("*", ANY, HasFlags(fs.FileMode(0o100))), "executable").
+		When(FileInfoOf("*.png", Gt(1024), ANY), "large image")
+
+	results, err := MatchWalk(testFS, rules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cmd/tool/main.go": "source",
+		"scripts/build.sh": "executable",
+		"assets/logo.png":  "large image",
+	}, results)
+}
+
+func TestFileInfoOf(t *testing.T) {
+	entries, err := fs.ReadDir(testFS, "scripts")
+	assert.NoError(t, err)
+
+	isMatched, _ := Match(entries[0]).When(FileInfoOf("build.*", Between(1, 100), ANY), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("build.sh").When(FileInfoOf("*", ANY, ANY), true).Result()
+	assert.False(t, isMatched)
+
+	pattern := FileInfoOf("[", ANY, ANY)
+	_, err = MatchWalk(testFS, NewRuleSet().When(pattern, true))
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
@@ -0,0 +1,53 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// HasFlags defines the pattern for integers with all bits of the mask set,
+// e.g. HasFlags(os.O_CREATE|os.O_EXCL). Values and masks can be of any
+// integer types, signed ones are compared by their two's complement bits.
+func HasFlags(mask interface{}) interface{} {
+	return flagsPattern("HasFlags", mask, func(bits, mask uint64) bool { return bits&mask == mask })
+}
+
+// AnyFlag defines the pattern for integers with at least one bit of the mask set.
+func AnyFlag(mask interface{}) interface{} {
+	return flagsPattern("AnyFlag", mask, func(bits, mask uint64) bool { return bits&mask != 0 })
+}
+
+// NoFlags defines the pattern for integers with no bits of the mask set.
+func NoFlags(mask interface{}) interface{} {
+	return flagsPattern("NoFlags", mask, func(bits, mask uint64) bool { return bits&mask == 0 })
+}
+
+func flagsPattern(name string, mask interface{}, accept func(bits, mask uint64) bool) interface{} {
+	maskBits, maskOk := toBits(mask)
+	pattern := funcPattern{name: fmt.Sprintf("%s(%#x)", name, maskBits)}
+	if !maskOk {
+		pattern.name = name + "(" + FormatPattern(mask) + ")"
+	}
+	pattern.check = func(value interface{}) bool {
+		if !maskOk {
+			panic(newPatternError(pattern, fmt.Sprintf("mask %v isn't an integer", mask)))
+		}
+
+		bits, ok := toBits(value)
+		return ok && accept(bits, maskBits)
+	}
+
+	return pattern
+}
+
+func toBits(value interface{}) (uint64, bool) {
+	val := reflect.ValueOf(value)
+	switch {
+	case isIntKind(val.Kind()):
+		return uint64(val.Int()), true
+	case isUintKind(val.Kind()):
+		return val.Uint(), true
+	}
+
+	return 0, false
+}
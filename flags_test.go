@@ -0,0 +1,47 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type perm uint8
+
+const (
+	permRead perm = 1 << iota
+	permWrite
+	permExec
+)
+
+func TestFlags(t *testing.T) {
+	classify := func(p perm) interface{} {
+		_, res := Match(p).
+			When(HasFlags(permRead|permWrite), "rw").
+			When(AnyFlag(permWrite|permExec), "w or x").
+			When(NoFlags(permRead|permWrite|permExec), "none").
+			When(ANY, "r").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "rw", classify(permRead|permWrite|permExec))
+	assert.Equal(t, "w or x", classify(permExec))
+	assert.Equal(t, "none", classify(0))
+	assert.Equal(t, "r", classify(permRead))
+}
+
+func TestFlags_MixedTypes(t *testing.T) {
+	isMatched, _ := Match(int64(0x0243)).When(HasFlags(0x41), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("0x41").When(AnyFlag(0x41), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestFlags_InvalidMask(t *testing.T) {
+	_, _, err := Match(1).When(HasFlags("rw"), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, `HasFlags("rw")`, FormatPattern(err.(*PatternError).Pattern))
+	assert.Equal(t, "HasFlags(0x41)", FormatPattern(HasFlags(permRead|0x40)))
+}
@@ -0,0 +1,110 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// patternFormatter is implemented by the patterns of the package which know
+// how they were built.
+type patternFormatter interface {
+	formatPattern() string
+}
+
+// FormatPattern returns a readable and deterministic representation of the
+// pattern for logs, error messages and golden-file tests, e.g.
+// `[HEAD, OneOf(1, 2), /^a+$/, TAIL]`. Map keys are sorted, patterns defined
+// outside of the package are formatted by their String method if they have one.
+func FormatPattern(pattern interface{}) string {
+	switch p := pattern.(type) {
+	case nil:
+		return "nil"
+	case matchKey:
+		return formatMatchKey(p)
+	case patternFormatter:
+		return p.formatPattern()
+	case *regexp.Regexp:
+		return "/" + p.String() + "/"
+	case string:
+		return strconv.Quote(p)
+	case int, float64, bool:
+		return fmt.Sprint(p)
+	case fmt.Stringer:
+		return p.String()
+	}
+
+	val := reflect.ValueOf(pattern)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "[" + formatList(sliceValueToSliceOfInterfaces(val)) + "]"
+	case reflect.Map:
+		return formatMap(val)
+	case reflect.Func:
+		return val.Type().String()
+	case reflect.Struct:
+		return fmt.Sprintf("%s%+v", val.Type(), pattern)
+	case reflect.Ptr:
+		if !val.IsNil() && val.Elem().Kind() == reflect.Struct {
+			return "&" + FormatPattern(val.Elem().Interface())
+		}
+	}
+
+	return fmt.Sprintf("%T(%v)", pattern, pattern)
+}
+
+func formatMatchKey(key matchKey) string {
+	switch key {
+	case ANY:
+		return "ANY"
+	case HEAD:
+		return "HEAD"
+	case TAIL:
+		return "TAIL"
+	case ANYSUBTREE:
+		return "ANYSUBTREE"
+	}
+
+	return fmt.Sprintf("matchKey(%d)", int(key))
+}
+
+func formatList(items []interface{}) string {
+	formatted := make([]string, len(items))
+	for i, item := range items {
+		formatted[i] = FormatPattern(item)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func formatStrings(items []string) string {
+	formatted := make([]string, len(items))
+	for i, item := range items {
+		formatted[i] = strconv.Quote(item)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func formatMap(val reflect.Value) string {
+	entries := make([]string, 0, val.Len())
+	iter := val.MapRange()
+	for iter.Next() {
+		entries = append(entries, FormatPattern(iter.Key().Interface())+": "+FormatPattern(iter.Value().Interface()))
+	}
+	sort.Strings(entries)
+
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+func formatBranches(matchItems []matchItem) string {
+	formatted := make([]string, len(matchItems))
+	for i, mi := range matchItems {
+		formatted[i] = FormatPattern(mi.pattern)
+	}
+
+	return strings.Join(formatted, " | ")
+}
@@ -0,0 +1,35 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPattern_Slice(t *testing.T) {
+	pattern := []interface{}{HEAD, OneOf(1, "two"), regexp.MustCompile("^a+$"), ANY, TAIL}
+
+	assert.Equal(t, `[HEAD, OneOf(1, "two"), /^a+$/, ANY, TAIL]`, FormatPattern(pattern))
+}
+
+func TestFormatPattern_MapIsSorted(t *testing.T) {
+	pattern := map[string]interface{}{"b": Between(1, 10), "a": int64(3), "c": nil}
+
+	assert.Equal(t, `{"a": int64(3), "b": Between(1, 10), "c": nil}`, FormatPattern(pattern))
+}
+
+func TestFormatPattern_PackagePatterns(t *testing.T) {
+	assert.Equal(t, `StructOf[match.person]().Field("Name", HasPrefix("a"))`, FormatPattern(StructOf[person]().Field("Name", HasPrefix("a"))))
+	assert.Equal(t, `Fields{"Age": Between(18, 65.5), "Name": ANY}`, FormatPattern(Fields{"Name": ANY, "Age": Between(18, 65.5)}))
+	assert.Equal(t, `Seq("a", Repeat(Alt(1, 2), 0, -1), Bind(ANY))`, FormatPattern(Seq("a", Many(Alt(1, 2)), Bind(ANY))))
+	assert.Equal(t, `Window(2, [SortedAsc, IsNull])`, FormatPattern(Window(2, []interface{}{SortedAsc, IsNull})))
+	assert.Equal(t, `Matcher(1 | CIDR("10.0.0.0/8"))`, FormatPattern(Match(nil).When(1, nil).When(CIDR("10.0.0.0/8"), nil)))
+}
+
+func TestFormatPattern_Values(t *testing.T) {
+	assert.Equal(t, "match.TestStruct{value:1}", FormatPattern(TestStruct{1}))
+	assert.Equal(t, "func(match.TestStruct) bool", FormatPattern(func(TestStruct) bool { return true }))
+	assert.Equal(t, "uint8(7)", FormatPattern(uint8(7)))
+	assert.Equal(t, "Arg[int]()", FormatPattern(Arg[int]()))
+}
@@ -0,0 +1,209 @@
+package match
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+type frameFieldKind int
+
+const (
+	fixedField frameFieldKind = iota
+	uintField
+	prefixedField
+	restField
+)
+
+type frameField struct {
+	kind    frameFieldKind
+	fixed   []byte
+	size    int
+	order   binary.ByteOrder
+	pattern interface{}
+}
+
+// FramePattern is the pattern for binary frames built field by field, e.g.
+//
+//	match.Frame().
+//		Bytes(0xCA, 0xFE).
+//		Uint16BE(match.Between(1, 3)).
+//		LengthPrefixed(2, binary.BigEndian, match.ANY).
+//		Rest(match.ANY)
+//
+// It matches []byte values consisting of exactly the fields. Every field
+// except Bytes binds its decoded value for the action, followed by the values
+// bound by its pattern. Integer fields are compared to number patterns by
+// value, so Uint16BE(0x0800) matches without a uint16 literal.
+type FramePattern struct {
+	fields []frameField
+}
+
+// Frame starts the frame pattern without fields.
+func Frame() *FramePattern {
+	return &FramePattern{}
+}
+
+// Bytes adds the field of the fixed bytes.
+func (fp *FramePattern) Bytes(b ...byte) *FramePattern {
+	return fp.add(frameField{kind: fixedField, fixed: append([]byte(nil), b...)})
+}
+
+// Uint8 adds the one-byte field matching the pattern.
+func (fp *FramePattern) Uint8(pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: uintField, size: 1, order: binary.BigEndian, pattern: pattern})
+}
+
+// Uint16BE adds the big endian uint16 field matching the pattern.
+func (fp *FramePattern) Uint16BE(pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: uintField, size: 2, order: binary.BigEndian, pattern: pattern})
+}
+
+// Uint16LE adds the little endian uint16 field matching the pattern.
+func (fp *FramePattern) Uint16LE(pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: uintField, size: 2, order: binary.LittleEndian, pattern: pattern})
+}
+
+// Uint32BE adds the big endian uint32 field matching the pattern.
+func (fp *FramePattern) Uint32BE(pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: uintField, size: 4, order: binary.BigEndian, pattern: pattern})
+}
+
+// Uint32LE adds the little endian uint32 field matching the pattern.
+func (fp *FramePattern) Uint32LE(pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: uintField, size: 4, order: binary.LittleEndian, pattern: pattern})
+}
+
+// LengthPrefixed adds the field of the bytes preceded by their length, which
+// is an unsigned integer of the size 1, 2 or 4 bytes in the byte order. The
+// pattern is matched against the bytes without the length.
+func (fp *FramePattern) LengthPrefixed(size int, order binary.ByteOrder, pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: prefixedField, size: size, order: order, pattern: pattern})
+}
+
+// Rest adds the field of the remaining bytes, possibly empty. It has to be
+// the last field.
+func (fp *FramePattern) Rest(pattern interface{}) *FramePattern {
+	return fp.add(frameField{kind: restField, pattern: pattern})
+}
+
+func (fp *FramePattern) add(field frameField) *FramePattern {
+	fp.fields = append(fp.fields, field)
+
+	return fp
+}
+
+func (fp *FramePattern) formatPattern() string {
+	var b strings.Builder
+	b.WriteString("Frame()")
+	for _, field := range fp.fields {
+		switch field.kind {
+		case fixedField:
+			formatted := make([]string, len(field.fixed))
+			for i, c := range field.fixed {
+				formatted[i] = fmt.Sprintf("0x%02x", c)
+			}
+			fmt.Fprintf(&b, ".Bytes(%s)", strings.Join(formatted, ", "))
+		case uintField:
+			fmt.Fprintf(&b, ".%s(%s)", uintFieldName(field), FormatPattern(field.pattern))
+		case prefixedField:
+			fmt.Fprintf(&b, ".LengthPrefixed(%d, binary.%v, %s)", field.size, field.order, FormatPattern(field.pattern))
+		case restField:
+			fmt.Fprintf(&b, ".Rest(%s)", FormatPattern(field.pattern))
+		}
+	}
+
+	return b.String()
+}
+
+func uintFieldName(field frameField) string {
+	if field.size == 1 {
+		return "Uint8"
+	}
+
+	suffix := "BE"
+	if field.order == binary.LittleEndian {
+		suffix = "LE"
+	}
+
+	return fmt.Sprintf("Uint%d%s", field.size*8, suffix)
+}
+
+func (fp *FramePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	data, ok := value.([]byte)
+	if !ok {
+		return nil, false
+	}
+
+	tolerant := *ms
+	tolerant.numericTolerance = true
+
+	var matchedItems []MatchItem
+	for i, field := range fp.fields {
+		var decoded interface{}
+		switch field.kind {
+		case fixedField:
+			if len(data) < len(field.fixed) || string(data[:len(field.fixed)]) != string(field.fixed) {
+				return nil, false
+			}
+
+			data = data[len(field.fixed):]
+			continue
+		case uintField:
+			n, ok := readUint(data, field.size, field.order)
+			if !ok {
+				return nil, false
+			}
+
+			decoded, data = n, data[field.size:]
+		case prefixedField:
+			if field.size != 1 && field.size != 2 && field.size != 4 {
+				panic(newPatternError(fp, fmt.Sprintf("length prefix of %d bytes, it can be 1, 2 or 4", field.size)))
+			}
+
+			n, ok := readUint(data, field.size, field.order)
+			length, _ := toBits(n)
+			if !ok || uint64(len(data)-field.size) < length {
+				return nil, false
+			}
+
+			end := field.size + int(length)
+			decoded, data = data[field.size:end], data[end:]
+		case restField:
+			if i != len(fp.fields)-1 {
+				panic(newPatternError(fp, "Rest must be the last field of the frame"))
+			}
+
+			decoded, data = data, data[len(data):]
+		}
+
+		fieldItems, matched := matchValue(&tolerant, field.pattern, decoded)
+		if !matched {
+			return nil, false
+		}
+
+		matchedItems = append(matchedItems, MatchItem{value: decoded})
+		matchedItems = append(matchedItems, fieldItems...)
+	}
+
+	return matchedItems, len(data) == 0
+}
+
+// readUint decodes the unsigned integer of the size at the start of the data
+// into uint8, uint16 or uint32.
+func readUint(data []byte, size int, order binary.ByteOrder) (interface{}, bool) {
+	if len(data) < size {
+		return nil, false
+	}
+
+	switch size {
+	case 1:
+		return data[0], true
+	case 2:
+		return order.Uint16(data), true
+	case 4:
+		return order.Uint32(data), true
+	}
+
+	return nil, false
+}
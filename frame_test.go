@@ -0,0 +1,58 @@
+package match
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrame(t *testing.T) {
+	frame := Frame().
+		Bytes(0xCA, 0xFE).
+		Uint8(Between(1, 3)).
+		Uint16LE(ANY).
+		LengthPrefixed(2, binary.BigEndian, HasPrefix("he")).
+		Rest(ANY)
+
+	data := []byte{0xCA, 0xFE, 0x02, 0x34, 0x12, 0x00, 0x05, 'h', 'e', 'l', 'l', 'o', 0xFF}
+	_, res := Match(data).
+		When(frame, func(version, id, payload, rest MatchItem) []interface{} {
+			return []interface{}{version.Value(), id.Value(), string(payload.Value().([]byte)), rest.Value()}
+		}).
+		Result()
+
+	assert.Equal(t, []interface{}{uint8(2), uint16(0x1234), "hello", []byte{0xFF}}, res)
+}
+
+func TestFrame_NoMatch(t *testing.T) {
+	frame := Frame().Bytes(0x01).Uint32BE(0x0800)
+
+	isMatched, _ := Match([]byte{0x01, 0x00, 0x00, 0x08, 0x00}).When(frame, true).Result()
+	assert.True(t, isMatched)
+
+	for _, data := range [][]byte{
+		{0x02, 0x00, 0x00, 0x08, 0x00},
+		{0x01, 0x00, 0x00, 0x08},
+		{0x01, 0x00, 0x00, 0x08, 0x00, 0x00},
+	} {
+		isMatched, _ := Match(data).When(frame, true).Result()
+		assert.False(t, isMatched, data)
+	}
+
+	isMatched, _ = Match([]byte{0x03, 'a'}).When(Frame().LengthPrefixed(1, binary.BigEndian, ANY), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestFrame_Invalid(t *testing.T) {
+	_, _, err := Match([]byte{0x01}).When(Frame().Rest(ANY).Uint8(ANY), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+
+	_, _, err = Match([]byte{0x01}).When(Frame().LengthPrefixed(3, binary.BigEndian, ANY), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+}
+
+func TestFrame_Format(t *testing.T) {
+	frame := Frame().Bytes(0xCA).Uint16BE(ANY).LengthPrefixed(1, binary.LittleEndian, ANY).Rest(ANY)
+	assert.Equal(t, "Frame().Bytes(0xca).Uint16BE(ANY).LengthPrefixed(1, binary.LittleEndian, ANY).Rest(ANY)", FormatPattern(frame))
+}
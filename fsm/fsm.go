@@ -0,0 +1,138 @@
+// Package fsm provides a finite state machine where states declare their
+// transitions as patterns on the incoming events.
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+)
+
+// Machine is a finite state machine. It is safe for concurrent use, events
+// change the state one at a time.
+type Machine struct {
+	mu      sync.Mutex
+	states  map[string]*State
+	current string
+}
+
+// State is a state of the Machine with its transitions and actions.
+type State struct {
+	name    string
+	rules   *match.RuleSet
+	onEnter func(event interface{})
+	onExit  func(event interface{})
+}
+
+// New creates the Machine in the initial state.
+func New(initial string) *Machine {
+	m := &Machine{states: map[string]*State{}, current: initial}
+	m.State(initial)
+
+	return m
+}
+
+// State returns the state with the given name, creating it on first use.
+func (m *Machine) State(name string) *State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.states[name]
+	if !ok {
+		state = &State{name: name, rules: match.NewRuleSet()}
+		m.states[name] = state
+	}
+
+	return state
+}
+
+// On adds the transition to the target state for events matching the pattern.
+// Transitions are checked in the order they were added.
+func (s *State) On(pattern interface{}, target string) *State {
+	s.rules.When(pattern, target)
+
+	return s
+}
+
+// OnEnter sets the action called with the event when the machine enters the state.
+func (s *State) OnEnter(action func(event interface{})) *State {
+	s.onEnter = action
+
+	return s
+}
+
+// OnExit sets the action called with the event when the machine leaves the state.
+func (s *State) OnExit(action func(event interface{})) *State {
+	s.onExit = action
+
+	return s
+}
+
+// Current returns the name of the current state.
+func (m *Machine) Current() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.current
+}
+
+// Apply applies the event to the current state. It returns false when no
+// transition of the state matches the event, the state is unchanged then.
+// Transitions to the same state run the exit and entry actions too. The
+// actions run after the transition without holding the lock, so they can
+// call Apply or Current of the machine.
+func (m *Machine) Apply(event interface{}) (bool, error) {
+	state, next, err := m.transition(event)
+	if next == nil || err != nil {
+		return false, err
+	}
+
+	if state.onExit != nil {
+		state.onExit(event)
+	}
+
+	if next.onEnter != nil {
+		next.onEnter(event)
+	}
+
+	return true, nil
+}
+
+// transition moves the machine to the state of the transition matching the
+// event, next is nil when no transition matches.
+func (m *Machine) transition(event interface{}) (state *State, next *State, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state = m.states[m.current]
+	_, target, err := state.rules.ResultE(event)
+	if errors.Is(err, match.ErrNoMatch) {
+		return state, nil, nil
+	}
+
+	if err != nil {
+		return state, nil, err
+	}
+
+	next, ok := m.states[target.(string)]
+	if !ok {
+		return state, nil, fmt.Errorf("fsm: unknown state %q in transition from %q", target, state.name)
+	}
+
+	m.current = next.name
+
+	return state, next, nil
+}
+
+// ApplyAll applies the events in order and stops on the first error.
+func (m *Machine) ApplyAll(events ...interface{}) error {
+	for _, event := range events {
+		if _, err := m.Apply(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package fsm
+
+import (
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+)
+
+type coin struct {
+	Cents int
+}
+
+func TestMachine_Turnstile(t *testing.T) {
+	var log []string
+	m := New("locked")
+	m.State("locked").
+		On(match.Fields{"Cents": match.Between(25, 100)}, "unlocked").
+		OnExit(func(event interface{}) { log = append(log, "exit locked") })
+	m.State("unlocked").
+		On("push", "locked").
+		OnEnter(func(event interface{}) { log = append(log, "enter unlocked") })
+
+	moved, err := m.Apply(coin{10})
+	assert.NoError(t, err)
+	assert.False(t, moved)
+	assert.Equal(t, "locked", m.Current())
+
+	assert.NoError(t, m.ApplyAll(coin{50}, "push", "push"))
+	assert.Equal(t, "locked", m.Current())
+	assert.Equal(t, []string{"exit locked", "enter unlocked"}, log)
+}
+
+func TestMachine_UnknownState(t *testing.T) {
+	m := New("idle")
+	m.State("idle").On("start", "running")
+
+	_, err := m.Apply("start")
+
+	assert.Error(t, err)
+	assert.Equal(t, "idle", m.Current())
+}
+
+func TestMachine_PatternError(t *testing.T) {
+	m := New("idle")
+	m.State("idle").On([]interface{}{1, match.HEAD}, "idle")
+
+	_, err := m.Apply([]int{1, 2})
+
+	assert.IsType(t, &match.PatternError{}, err)
+}
+
+func TestMachine_NoTransitionIsNotError(t *testing.T) {
+	m := New("idle")
+	m.State("idle").On("start", "idle")
+
+	moved, err := m.Apply("stop")
+
+	assert.False(t, moved)
+	assert.NoError(t, err)
+}
+
+func TestMachine_ActionsCanApply(t *testing.T) {
+	var entered []string
+	m := New("idle")
+	m.State("idle").On("start", "starting")
+	m.State("starting").
+		On("started", "running").
+		OnEnter(func(event interface{}) {
+			entered = append(entered, m.Current())
+			_, err := m.Apply("started")
+			assert.NoError(t, err)
+		})
+	m.State("running")
+
+	moved, err := m.Apply("start")
+
+	assert.True(t, moved)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"starting"}, entered)
+	assert.Equal(t, "running", m.Current())
+}
@@ -0,0 +1,45 @@
+package match
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FuncSig defines the pattern for func values with exactly the given
+// parameter and result types, e.g. for registries dispatching on callback
+// shapes. The variadic parameter of a func is matched by its slice type.
+func FuncSig(inTypes []reflect.Type, outTypes []reflect.Type) interface{} {
+	name := "FuncSig(func(" + formatTypes(inTypes) + ") (" + formatTypes(outTypes) + "))"
+	return funcPattern{name, func(value interface{}) bool {
+		t := reflect.TypeOf(value)
+		if t == nil || t.Kind() != reflect.Func || t.NumIn() != len(inTypes) || t.NumOut() != len(outTypes) {
+			return false
+		}
+
+		for i, in := range inTypes {
+			if t.In(i) != in {
+				return false
+			}
+		}
+
+		for i, out := range outTypes {
+			if t.Out(i) != out {
+				return false
+			}
+		}
+
+		return true
+	}}
+}
+
+func formatTypes(types []reflect.Type) string {
+	formatted := make([]string, len(types))
+	for i, t := range types {
+		formatted[i] = "nil"
+		if t != nil {
+			formatted[i] = t.String()
+		}
+	}
+
+	return strings.Join(formatted, ", ")
+}
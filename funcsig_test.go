@@ -0,0 +1,31 @@
+package match
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncSig(t *testing.T) {
+	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	handler := FuncSig([]reflect.Type{contextType, reflect.TypeOf("")}, []reflect.Type{errorType})
+	callback := FuncSig(nil, nil)
+
+	match := func(val interface{}) interface{} {
+		_, res := Match(val).
+			When(handler, "handler").
+			When(callback, "callback").
+			When(ANY, "unknown").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "handler", match(func(context.Context, string) error { return nil }))
+	assert.Equal(t, "callback", match(func() {}))
+	assert.Equal(t, "unknown", match(func(string) error { return nil }))
+	assert.Equal(t, "unknown", match(42))
+	assert.Equal(t, "FuncSig(func(context.Context, string) (error))", FormatPattern(handler))
+}
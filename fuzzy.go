@@ -0,0 +1,57 @@
+package match
+
+import "strconv"
+
+// Fuzzy defines the pattern for strings within the Levenshtein distance
+// maxDistance from the target. The distance is counted in runes.
+func Fuzzy(target string, maxDistance int) interface{} {
+	targetRunes := []rune(target)
+	return funcPattern{"Fuzzy(" + strconv.Quote(target) + ", " + strconv.Itoa(maxDistance) + ")", func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && levenshtein([]rune(str), targetRunes, maxDistance) <= maxDistance
+	}}
+}
+
+// levenshtein returns the edit distance of the strings, or a value greater
+// than limit as soon as the distance is known to exceed it.
+func levenshtein(a, b []rune, limit int) int {
+	if abs(len(a)-len(b)) > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+
+		if rowMin > limit {
+			return limit + 1
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
@@ -0,0 +1,32 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzy(t *testing.T) {
+	classify := func(input string) interface{} {
+		_, res := Match(input).
+			When(Fuzzy("yes", 1), "yes").
+			When(Fuzzy("cancel", 2), "cancel").
+			When(ANY, "unknown").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "yes", classify("yes"))
+	assert.Equal(t, "yes", classify("yess"))
+	assert.Equal(t, "cancel", classify("cancle"))
+	assert.Equal(t, "cancel", classify("cncel"))
+	assert.Equal(t, "unknown", classify("nope"))
+	assert.Equal(t, `Fuzzy("yes", 1)`, FormatPattern(Fuzzy("yes", 1)))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 3, levenshtein([]rune("kitten"), []rune("sitting"), 10))
+	assert.Equal(t, 1, levenshtein([]rune("café"), []rune("cafe"), 10))
+	assert.Equal(t, 0, levenshtein(nil, nil, 0))
+	assert.Equal(t, 2, levenshtein([]rune("kitten"), []rune("sitting"), 1))
+}
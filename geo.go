@@ -0,0 +1,91 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GeoPoint is implemented by the point types which InBBox and WithinKm
+// can't read by the field names.
+type GeoPoint interface {
+	LatLon() (lat float64, lon float64)
+}
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0088
+
+// InBBox defines the pattern for points within the bounding box, bounds
+// included, in degrees. The box crosses the antimeridian when minLon is
+// greater than maxLon. Points can be GeoPoint, [2]float64 or []float64 pairs
+// of latitude and longitude, or structs (or pointers to them) with number
+// fields named Lat or Latitude and Lon, Lng or Longitude.
+func InBBox(minLat float64, minLon float64, maxLat float64, maxLon float64) interface{} {
+	return funcPattern{fmt.Sprintf("InBBox(%g, %g, %g, %g)", minLat, minLon, maxLat, maxLon), func(value interface{}) bool {
+		lat, lon, ok := latLonOf(value)
+		if !ok || lat < minLat || lat > maxLat {
+			return false
+		}
+
+		if minLon <= maxLon {
+			return lon >= minLon && lon <= maxLon
+		}
+
+		return lon >= minLon || lon <= maxLon
+	}}
+}
+
+// WithinKm defines the pattern for points at most km kilometers away from
+// the point by the great-circle distance, points are the same as for InBBox.
+func WithinKm(lat float64, lon float64, km float64) interface{} {
+	return funcPattern{fmt.Sprintf("WithinKm(%g, %g, %g)", lat, lon, km), func(value interface{}) bool {
+		pointLat, pointLon, ok := latLonOf(value)
+		return ok && haversineKm(lat, lon, pointLat, pointLon) <= km
+	}}
+}
+
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*toRad, (lon2-lon1)*toRad
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func latLonOf(value interface{}) (float64, float64, bool) {
+	switch v := value.(type) {
+	case GeoPoint:
+		lat, lon := v.LatLon()
+		return lat, lon, true
+	case [2]float64:
+		return v[0], v[1], true
+	case []float64:
+		if len(v) != 2 {
+			return 0, 0, false
+		}
+		return v[0], v[1], true
+	}
+
+	structValue, ok := structValueOf(value)
+	if !ok {
+		return 0, 0, false
+	}
+
+	var lat, lon float64
+	var hasLat, hasLon bool
+	for i := 0; i < structValue.NumField(); i++ {
+		field := structValue.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		switch strings.ToLower(field.Name) {
+		case "lat", "latitude":
+			lat, hasLat = toFloat(structValue.Field(i))
+		case "lon", "lng", "longitude":
+			lon, hasLon = toFloat(structValue.Field(i))
+		}
+	}
+
+	return lat, lon, hasLat && hasLon
+}
@@ -0,0 +1,41 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type locationEvent struct {
+	DeviceID string
+	Lat, Lng float64
+}
+
+type cityPoint struct{ lat, lon float64 }
+
+func (p cityPoint) LatLon() (float64, float64) { return p.lat, p.lon }
+
+func TestGeo(t *testing.T) {
+	zone := func(point interface{}) interface{} {
+		_, res := Match(point).
+			When(WithinKm(52.5200, 13.4050, 30), "berlin").
+			When(InBBox(47.27, 5.87, 55.06, 15.04), "germany").
+			When(InBBox(-20, 170, 20, -170), "pacific").
+			When(ANY, "elsewhere").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "berlin", zone(locationEvent{"a", 52.40, 13.06}))
+	assert.Equal(t, "germany", zone(&locationEvent{"b", 48.1351, 11.5820}))
+	assert.Equal(t, "germany", zone([2]float64{53.5511, 9.9937}))
+	assert.Equal(t, "pacific", zone([]float64{0, 179.5}))
+	assert.Equal(t, "pacific", zone(cityPoint{-5, -175}))
+	assert.Equal(t, "elsewhere", zone(cityPoint{48.8566, 2.3522}))
+	assert.Equal(t, "elsewhere", zone([]float64{1, 2, 3}))
+	assert.Equal(t, "elsewhere", zone("52.52,13.40"))
+}
+
+func TestHaversineKm(t *testing.T) {
+	assert.InDelta(t, 878, haversineKm(52.5200, 13.4050, 48.8566, 2.3522), 2)
+}
@@ -0,0 +1,146 @@
+package match
+
+import "reflect"
+
+// HasEdge defines the pattern for adjacency-map graphs with the directed
+// edge from the node a to the node b. Graphs are maps from nodes to
+// slices or arrays of their successors, like map[string][]string, or to
+// sets of them, like map[string]map[string]bool or map[string]map[string]struct{}.
+func HasEdge(a interface{}, b interface{}) interface{} {
+	return funcPattern{"HasEdge(" + FormatPattern(a) + ", " + FormatPattern(b) + ")", func(value interface{}) bool {
+		graph, ok := adjacencyOf(value)
+		if !ok {
+			return false
+		}
+
+		for _, successor := range graph[a] {
+			if successor == b {
+				return true
+			}
+		}
+
+		return false
+	}}
+}
+
+type degreePattern struct {
+	node    interface{}
+	pattern interface{}
+}
+
+// NodeDegree defines the pattern for adjacency-map graphs, see HasEdge,
+// containing the node whose number of successors matches the pattern, e.g.
+// NodeDegree("app", Gt(10)). Nodes which are only successors have the degree 0.
+func NodeDegree(node interface{}, pattern interface{}) interface{} {
+	return degreePattern{node, pattern}
+}
+
+func (dp degreePattern) formatPattern() string {
+	return "NodeDegree(" + FormatPattern(dp.node) + ", " + FormatPattern(dp.pattern) + ")"
+}
+
+func (dp degreePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	graph, ok := adjacencyOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	successors, ok := graph[dp.node]
+	if !ok && !isSuccessor(graph, dp.node) {
+		return nil, false
+	}
+
+	return matchValue(ms, dp.pattern, len(successors))
+}
+
+func isSuccessor(graph map[interface{}][]interface{}, node interface{}) bool {
+	for _, successors := range graph {
+		for _, successor := range successors {
+			if successor == node {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsDAG is the pattern for adjacency-map graphs without cycles, see HasEdge.
+var IsDAG interface{} = funcPattern{"IsDAG", func(value interface{}) bool {
+	graph, ok := adjacencyOf(value)
+	if !ok {
+		return false
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[interface{}]int, len(graph))
+	var acyclic func(node interface{}) bool
+	acyclic = func(node interface{}) bool {
+		switch state[node] {
+		case visiting:
+			return false
+		case visited:
+			return true
+		}
+
+		state[node] = visiting
+		for _, successor := range graph[node] {
+			if !acyclic(successor) {
+				return false
+			}
+		}
+		state[node] = visited
+
+		return true
+	}
+
+	for node := range graph {
+		if !acyclic(node) {
+			return false
+		}
+	}
+
+	return true
+}}
+
+// adjacencyOf converts the graph to successors by node.
+func adjacencyOf(value interface{}) (map[interface{}][]interface{}, bool) {
+	graph := reflect.ValueOf(value)
+	if graph.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	valueKind := graph.Type().Elem().Kind()
+	if valueKind != reflect.Slice && valueKind != reflect.Array && valueKind != reflect.Map {
+		return nil, false
+	}
+
+	adjacency := make(map[interface{}][]interface{}, graph.Len())
+	iter := graph.MapRange()
+	for iter.Next() {
+		successors := iter.Value()
+		var nodes []interface{}
+		if valueKind == reflect.Map {
+			setIter := successors.MapRange()
+			for setIter.Next() {
+				// false members of map[K]bool sets aren't edges
+				if member := setIter.Value(); member.Kind() != reflect.Bool || member.Bool() {
+					nodes = append(nodes, setIter.Key().Interface())
+				}
+			}
+		} else {
+			for i := 0; i < successors.Len(); i++ {
+				nodes = append(nodes, successors.Index(i).Interface())
+			}
+		}
+
+		adjacency[iter.Key().Interface()] = nodes
+	}
+
+	return adjacency, true
+}
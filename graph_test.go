@@ -0,0 +1,55 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphPatterns(t *testing.T) {
+	deps := map[string][]string{
+		"app":    {"lib", "log"},
+		"lib":    {"log"},
+		"log":    {},
+		"plugin": {"app"},
+	}
+
+	classify := func(graph interface{}) interface{} {
+		_, res := Match(graph).
+			When(HasEdge("log", "app"), "log depends on app").
+			When(IsDAG, "dag").
+			When(ANY, "cyclic").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "dag", classify(deps))
+	assert.Equal(t, "cyclic", classify(map[int][2]int{1: {2, 2}, 2: {3, 3}, 3: {1, 1}}))
+	assert.Equal(t, "log depends on app", classify(map[string]map[string]bool{"log": {"app": true}, "app": {"log": true}}))
+	assert.Equal(t, "dag", classify(map[string]map[string]bool{"log": {"app": false}, "app": {"log": true}}))
+
+	isMatched, _ := Match(deps).When(HasEdge("app", "lib"), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("app -> lib").When(HasEdge("app", "lib"), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestNodeDegree(t *testing.T) {
+	deps := map[string]map[string]struct{}{
+		"app": {"lib": {}, "log": {}},
+		"lib": {"log": {}},
+	}
+
+	_, res := Match(deps).
+		When(NodeDegree("app", Gt(5)), "fan-out").
+		When(NodeDegree("app", 2), "two").
+		Result()
+	assert.Equal(t, "two", res)
+
+	isMatched, _ := Match(deps).When(NodeDegree("log", 0), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(deps).When(NodeDegree("missing", 0), true).Result()
+	assert.False(t, isMatched)
+}
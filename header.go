@@ -0,0 +1,74 @@
+package match
+
+import (
+	"net/http"
+	"net/textproto"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// Header defines the pattern for http.Header, textproto.MIMEHeader and
+// map[string][]string values by patterns for the headers, e.g.
+// Header{"content-type": MIME("application/json"), "X-Request-*": ANY}.
+// Names are canonicalized, names with wildcards (see Glob) are matched
+// case-insensitively and match if any of the headers they match has a
+// matching value. Like in MatchValues, a pattern matches the first value
+// of the header, EveryElem, SomeElem and slice patterns match all of them.
+type Header map[string]interface{}
+
+func (header Header) formatPattern() string {
+	return "Header" + formatMap(reflect.ValueOf(map[string]interface{}(header)))
+}
+
+func (header Header) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	headers, ok := headersOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	for name, pattern := range header {
+		if !header.matchHeader(ms, headers, name, pattern) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+func (header Header) matchHeader(ms *matchState, headers map[string][]string, name string, pattern interface{}) bool {
+	if !strings.ContainsAny(name, "*?[") {
+		values, ok := headers[textproto.CanonicalMIMEHeaderKey(name)]
+		if !ok {
+			values, ok = headers[name]
+		}
+
+		return ok && matchValueBool(ms, pattern, multiValue(values))
+	}
+
+	if _, err := path.Match(name, ""); err != nil {
+		panic(&PatternError{Pattern: header, Err: err})
+	}
+
+	for key, values := range headers {
+		matched, _ := path.Match(strings.ToLower(name), strings.ToLower(key))
+		if matched && matchValueBool(ms, pattern, multiValue(values)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func headersOf(value interface{}) (map[string][]string, bool) {
+	switch v := value.(type) {
+	case http.Header:
+		return v, true
+	case textproto.MIMEHeader:
+		return v, true
+	case map[string][]string:
+		return v, true
+	}
+
+	return nil, false
+}
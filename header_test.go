@@ -0,0 +1,47 @@
+package match
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Add("Accept", "text/html")
+	header.Add("Accept", "application/json")
+	header.Set("X-Request-Id", "abc")
+
+	_, res := Match(header).
+		When(Header{"authorization": ANY}, "authenticated").
+		When(Header{
+			"content-type": MIME("application/json"),
+			"accept":       SomeElem(HasPrefix("application/json")),
+			"x-request-*":  regexp.MustCompile("^[a-z]+$"),
+		}, "api").
+		Result()
+	assert.Equal(t, "api", res)
+
+	isMatched, _ := Match(header).When(Header{"X-Trace-*": ANY}, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(header).When(Header{"Accept": EveryElem(HasPrefix("text/"))}, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(map[string][]string{"x-lower": {"1"}}).When(Header{"x-lower": 1}, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(map[string][]string{"x-lower": {"1"}}).When(Header{"x-lower": "1"}, true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestHeader_InvalidGlob(t *testing.T) {
+	pattern := Header{"X-[": ANY}
+	_, _, err := Match(http.Header{}).When(pattern, true).ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
@@ -0,0 +1,123 @@
+package match
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// IsUUID is the pattern for UUIDs in the canonical string form
+	// (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx) or [16]byte values of the RFC 4122 variant.
+	IsUUID interface{} = funcPattern{"IsUUID", func(value interface{}) bool {
+		_, ok := uuidBytes(value)
+		return ok
+	}}
+	// IsULID is the pattern for ULIDs as 26 characters Crockford's base32
+	// strings or [16]byte values.
+	IsULID interface{} = funcPattern{"IsULID", isULID}
+)
+
+// UUIDv defines the pattern for UUIDs of the given version.
+func UUIDv(version int) interface{} {
+	return funcPattern{"UUIDv(" + strconv.Itoa(version) + ")", func(value interface{}) bool {
+		uuid, ok := uuidBytes(value)
+		return ok && int(uuid[6]>>4) == version
+	}}
+}
+
+// uuidBytes parses the UUID value and checks its variant.
+func uuidBytes(value interface{}) ([16]byte, bool) {
+	var uuid [16]byte
+	if str, ok := value.(string); ok {
+		if len(str) != 36 {
+			return uuid, false
+		}
+
+		// dashes must be exactly at 8, 13, 18 and 23, so the other 32
+		// characters are the hex digits
+		var digits [32]byte
+		n := 0
+		for i := 0; i < len(str); i++ {
+			isDash := i == 8 || i == 13 || i == 18 || i == 23
+			if isDash != (str[i] == '-') {
+				return uuid, false
+			}
+			if !isDash {
+				digits[n] = str[i]
+				n++
+			}
+		}
+
+		for i := range uuid {
+			hi, ok1 := fromHex(digits[2*i])
+			lo, ok2 := fromHex(digits[2*i+1])
+			if !ok1 || !ok2 {
+				return uuid, false
+			}
+
+			uuid[i] = hi<<4 | lo
+		}
+	} else if bytes, ok := bytes16(value); ok {
+		uuid = bytes
+	} else {
+		return uuid, false
+	}
+
+	return uuid, uuid[8]&0xc0 == 0x80
+}
+
+// bytes16 converts [16]byte values, including named types like uuid.UUID.
+func bytes16(value interface{}) ([16]byte, bool) {
+	var res [16]byte
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Array || val.Len() != 16 || val.Type().Elem().Kind() != reflect.Uint8 {
+		return res, false
+	}
+
+	reflect.Copy(reflect.ValueOf(&res).Elem(), val)
+
+	return res, true
+}
+
+func fromHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+
+	return 0, false
+}
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func isULID(value interface{}) bool {
+	str, ok := value.(string)
+	if !ok {
+		_, ok = bytes16(value)
+		return ok
+	}
+
+	// the first character holds only 3 bits of the 128 bits timestamp and entropy
+	if len(str) != 26 || str[0] > '7' {
+		return false
+	}
+
+	// the string is checked by bytes, so non-ASCII characters which upper
+	// case to the alphabet, like 'ſ', aren't accepted
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if strings.IndexByte(crockfordBase32, c) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
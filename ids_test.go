@@ -0,0 +1,83 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_IsUUID(t *testing.T) {
+	_, res := Match("f47ac10b-58cc-4372-a567-0e02b2c3d479").
+		When(UUIDv(1), "v1").
+		When(UUIDv(4), "v4").
+		When(IsUUID, "uuid").
+		Result()
+
+	assert.Equal(t, "v4", res)
+}
+
+func TestMatch_IsUUIDInvalid(t *testing.T) {
+	_, res := Match("f47ac10b-58cc-4372-a567-0e02b2c3d47z").
+		When(IsUUID, "uuid").
+		When(ANY, "not uuid").
+		Result()
+
+	assert.Equal(t, "not uuid", res)
+}
+
+type uuidType [16]byte
+
+func TestMatch_IsUUIDBytes(t *testing.T) {
+	id := uuidType{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	isMatched, _ := Match(id).
+		When(UUIDv(1), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_IsULID(t *testing.T) {
+	isMatched, _ := Match("01ARZ3NDEKTSV4RRFFQ69G5FAV").
+		When(IsULID, true).
+		Result()
+
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("81ARZ3NDEKTSV4RRFFQ69G5FAV").
+		When(IsULID, true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_IsUUIDMisplacedDash(t *testing.T) {
+	for _, value := range []string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d4-9",
+		"f47ac10b58cc-4372-a567-0e02b2c3d479-",
+		"f47ac10b-58cc-4372-a5670e02b2c3d479a",
+	} {
+		_, res := Match(value).
+			When(IsUUID, "uuid").
+			When(ANY, "not uuid").
+			Result()
+
+		assert.Equal(t, "not uuid", res, value)
+	}
+}
+
+func TestMatch_IsULIDAlphabet(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"01arz3ndektsv4rrffq69g5fav": true,
+		"7ZZZZZZZZZZZZZZZZZZZZZZZZZ": true,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAI": false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAL": false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAO": false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU": false,
+		"01ARZ3NDEKTSV4RRFFQ69G5F-V": false,
+		"01ARZ3NDEKTSV4RRFFQ69G5ſV":  false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FA":  false,
+	} {
+		isMatched, _ := Match(value).When(IsULID, true).Result()
+		assert.Equal(t, expected, isMatched, value)
+	}
+}
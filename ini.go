@@ -0,0 +1,72 @@
+package match
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MatchINI function parses the INI content and returns the Matcher for it as
+// map[string]interface{}: keys before the first section are at the top
+// level, sections are nested maps of their keys, e.g.
+// map[string]interface{}{"server": map[string]interface{}{"port": Between(1, 65535)}}.
+// Values are strings which also match number patterns like in MatchCSVRecord.
+// Lines starting with ';' or '#' are comments, keys are separated from values
+// by '=' or ':' and quoted values are unquoted.
+func MatchINI(raw []byte) (*Matcher, error) {
+	config, err := parseINI(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := Match(config).WithNumericTolerance()
+	matcher.state.numericStrings = true
+
+	return matcher, nil
+}
+
+func parseINI(raw []byte) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	section := config
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("match: INI line %d: unterminated section %q", lineNo, line)
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			existing, ok := config[name].(map[string]interface{})
+			if !ok {
+				existing = map[string]interface{}{}
+				config[name] = existing
+			}
+			section = existing
+			continue
+		}
+
+		sep := strings.IndexAny(line, "=:")
+		if sep <= 0 {
+			return nil, fmt.Errorf("match: INI line %d: expected key = value, got %q", lineNo, line)
+		}
+
+		value := strings.TrimSpace(line[sep+1:])
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+
+		section[strings.TrimSpace(line[:sep])] = value
+	}
+
+	return config, scanner.Err()
+}
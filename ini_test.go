@@ -0,0 +1,43 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testINI = `
+; global settings
+name = "my app"
+
+[server]
+host: 0.0.0.0
+port = 8080
+
+[database]
+url = 'postgres://db/app'
+`
+
+func TestMatchINI(t *testing.T) {
+	matcher, err := MatchINI([]byte(testINI))
+	assert.NoError(t, err)
+
+	_, res := matcher.
+		When(map[string]interface{}{"server": map[string]interface{}{"port": Lt(1024)}}, "privileged").
+		When(map[string]interface{}{
+			"name":     "my app",
+			"server":   map[string]interface{}{"host": ANY, "port": 8080},
+			"database": map[string]interface{}{"url": HasPrefix("postgres://")},
+		}, "ok").
+		Result()
+
+	assert.Equal(t, "ok", res)
+}
+
+func TestMatchINI_Invalid(t *testing.T) {
+	_, err := MatchINI([]byte("[server\nport = 1"))
+	assert.Error(t, err)
+
+	_, err = MatchINI([]byte("just words"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+package match
+
+// Iterable is implemented by map types which aren't Go maps, like ordered
+// maps, so they can be matched by map patterns and MapOf. *sync.Map
+// implements it as is.
+type Iterable interface {
+	Range(f func(key, value interface{}) bool)
+}
+
+func iterableMap(iterable Iterable) map[interface{}]interface{} {
+	entries := map[interface{}]interface{}{}
+	iterable.Range(func(key, value interface{}) bool {
+		entries[key] = value
+		return true
+	})
+
+	return entries
+}
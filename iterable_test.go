@@ -0,0 +1,50 @@
+package match
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (om *orderedMap) Range(f func(key, value interface{}) bool) {
+	for _, k := range om.keys {
+		if !f(k, om.values[k]) {
+			return
+		}
+	}
+}
+
+func TestMatch_SyncMap(t *testing.T) {
+	var m sync.Map
+	m.Store("status", "ok")
+	m.Store("code", 200)
+
+	_, res := Match(&m).
+		When(map[string]interface{}{"status": "failed"}, "failed").
+		When(map[string]interface{}{"status": "ok", "code": ANY}, "ok").
+		Result()
+
+	assert.Equal(t, "ok", res)
+}
+
+func TestMatch_Iterable(t *testing.T) {
+	om := &orderedMap{
+		keys:   []string{"a", "b"},
+		values: map[string]interface{}{"a": 1, "b": 2},
+	}
+
+	isMatched, _ := Match(om).When(map[string]interface{}{"b": 2}, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(om).When(MapOf[string, int](ANY, OneOf(1, 2)), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(om).When(map[string]interface{}{"c": ANY}, true).Result()
+	assert.False(t, isMatched)
+}
@@ -0,0 +1,54 @@
+package match
+
+import "reflect"
+
+// Labels defines the pattern for label sets like the ones of Prometheus
+// series, by patterns for the label values, e.g. Labels{"job": "api",
+// "env": OneOf("prod", "staging"), "instance": Glob("10.0.*")}. Like in
+// Prometheus matchers, a missing label has the empty value, so Labels{"env": ""}
+// matches label sets without env.
+//
+// The value can be a map with string keys and values, also of named string
+// types, or a value with a Map() map[string]string method like labels.Labels.
+type Labels map[string]interface{}
+
+func (labels Labels) formatPattern() string {
+	return "Labels" + formatMap(reflect.ValueOf(map[string]interface{}(labels)))
+}
+
+func (labels Labels) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	labelSet, ok := labelSetOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	for name, pattern := range labels {
+		if !matchValueBool(ms, pattern, labelSet[name]) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+func labelSetOf(value interface{}) (map[string]string, bool) {
+	switch v := value.(type) {
+	case map[string]string:
+		return v, true
+	case interface{ Map() map[string]string }:
+		return v.Map(), true
+	}
+
+	mapValue := reflect.ValueOf(value)
+	if mapValue.Kind() != reflect.Map || mapValue.Type().Key().Kind() != reflect.String || mapValue.Type().Elem().Kind() != reflect.String {
+		return nil, false
+	}
+
+	labelSet := make(map[string]string, mapValue.Len())
+	iter := mapValue.MapRange()
+	for iter.Next() {
+		labelSet[iter.Key().String()] = iter.Value().String()
+	}
+
+	return labelSet, true
+}
@@ -0,0 +1,42 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLabelName string
+
+type testLabelValue string
+
+type testLabels []struct{ name, value string }
+
+func (tl testLabels) Map() map[string]string {
+	m := map[string]string{}
+	for _, l := range tl {
+		m[l.name] = l.value
+	}
+	return m
+}
+
+func TestLabels(t *testing.T) {
+	route := func(labels interface{}) interface{} {
+		_, res := Match(labels).
+			When(Labels{"severity": "critical", "env": OneOf("prod", "staging")}, "pager").
+			When(Labels{"job": Glob("api-*"), "team": ""}, "unowned api").
+			When(Labels{"job": regexp.MustCompile("^db")}, "dba").
+			When(ANY, "default").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "pager", route(map[string]string{"severity": "critical", "env": "prod", "job": "api"}))
+	assert.Equal(t, "unowned api", route(map[string]string{"job": "api-gateway"}))
+	assert.Equal(t, "default", route(map[string]string{"job": "api-gateway", "team": "edge"}))
+	assert.Equal(t, "dba", route(map[testLabelName]testLabelValue{"job": "db-primary"}))
+	assert.Equal(t, "dba", route(testLabels{{"job", "db"}}))
+	assert.Equal(t, "default", route(map[string]int{"job": 1}))
+	assert.Equal(t, `Labels{"job": "api"}`, FormatPattern(Labels{"job": "api"}))
+}
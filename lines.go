@@ -0,0 +1,36 @@
+package match
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// ForEachLine classifies every line of the reader by the branches added by
+// the build func, usually string and regexp patterns, and streams the result
+// values of the matched lines to handle. Lines which match no branch are
+// passed to unmatched, which can be nil. The error is returned when the reader
+// fails or a pattern is invalid, see PatternError.
+func ForEachLine(r io.Reader, build func(rules *RuleSet) *RuleSet, handle func(line string, result interface{}), unmatched func(line string)) error {
+	rules := build(NewRuleSet())
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		_, res, err := rules.ResultE(line)
+		if errors.Is(err, ErrNoMatch) {
+			if unmatched != nil {
+				unmatched(line)
+			}
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		handle(line, res)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,42 @@
+package match
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachLine(t *testing.T) {
+	log := "INFO started\nERROR disk full\n\nWARN slow request 1200ms\nDEBUG noise\n"
+
+	var results []interface{}
+	var skipped []string
+	err := ForEachLine(strings.NewReader(log),
+		func(rules *RuleSet) *RuleSet {
+			return rules.
+				When(HasPrefix("ERROR"), "error").
+				When(regexp.MustCompile(`^WARN .* \d+ms$`), "slow").
+				When(HasPrefix("INFO"), func() interface{} { return "info" })
+		},
+		func(line string, result interface{}) {
+			results = append(results, result)
+		},
+		func(line string) {
+			skipped = append(skipped, line)
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"info", "error", "slow"}, results)
+	assert.Equal(t, []string{"", "DEBUG noise"}, skipped)
+}
+
+func TestForEachLine_PatternError(t *testing.T) {
+	err := ForEachLine(strings.NewReader("a\n"),
+		func(rules *RuleSet) *RuleSet { return rules.When(Glob("["), true) },
+		func(string, interface{}) {},
+		nil)
+
+	assert.IsType(t, &PatternError{}, err)
+}
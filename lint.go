@@ -0,0 +1,53 @@
+package match
+
+import "fmt"
+
+// LintWarning describes a branch which can never be matched because an
+// earlier branch matches every value it does.
+type LintWarning struct {
+	// Branch is the index of the shadowed branch in the order it was added.
+	Branch int
+	// ShadowedBy is the index of the earlier branch.
+	ShadowedBy int
+	Pattern    interface{}
+}
+
+func (w LintWarning) String() string {
+	return fmt.Sprintf("branch %d %s is shadowed by branch %d", w.Branch, FormatPattern(w.Pattern), w.ShadowedBy)
+}
+
+// Lint returns warnings for the branches which can never be matched, e.g.
+// literals after ANY. The check is conservative, func patterns and custom
+// matchers are only reported when they follow ANY.
+func (matcher *Matcher) Lint() []LintWarning {
+	order := make([]int, len(matcher.matchItems))
+	for i := range order {
+		order[i] = i
+	}
+
+	return lintBranches(matcher.matchItems, order)
+}
+
+// Lint returns warnings for the branches which can never be matched in the
+// current evaluation order, see Matcher.Lint.
+func (rs *RuleSet) Lint() []LintWarning {
+	rs.mu.Lock()
+	matchItems, order := rs.matcher.matchItems, rs.order
+	rs.mu.Unlock()
+
+	return lintBranches(matchItems, order)
+}
+
+func lintBranches(matchItems []matchItem, order []int) []LintWarning {
+	var warnings []LintWarning
+	for pos, j := range order {
+		for _, i := range order[:pos] {
+			if Subsumes(matchItems[i].pattern, matchItems[j].pattern) {
+				warnings = append(warnings, LintWarning{Branch: j, ShadowedBy: i, Pattern: matchItems[j].pattern})
+				break
+			}
+		}
+	}
+
+	return warnings
+}
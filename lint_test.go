@@ -0,0 +1,67 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint_LiteralAfterAny(t *testing.T) {
+	warnings := Match(1).
+		When(ANY, "any").
+		When(1, "one").
+		Lint()
+
+	assert.Equal(t, []LintWarning{{Branch: 1, ShadowedBy: 0, Pattern: 1}}, warnings)
+	assert.Equal(t, "branch 1 1 is shadowed by branch 0", warnings[0].String())
+}
+
+func TestLint_OneOfAndSlices(t *testing.T) {
+	warnings := Match(nil).
+		When(OneOf(1, 2, 3), "small").
+		When(2, "two").
+		When([]interface{}{1, ANY}, "starts with one").
+		When([]interface{}{1, 2}, "one two").
+		When([]interface{}{HEAD, 2}, "ends with two").
+		When(regexp.MustCompile("^a"), "a").
+		When(regexp.MustCompile("^a"), "a again").
+		Lint()
+
+	assert.Equal(t, []int{1, 3, 6}, lintedBranches(warnings))
+}
+
+func TestLint_NoWarnings(t *testing.T) {
+	assert.Empty(t, Match(1).
+		When(1, "one").
+		When(int64(1), "int64 one").
+		When(func(i int) bool { return i > 0 }, "positive").
+		When(ANY, "any").
+		Lint())
+}
+
+func TestLint_MapKeyTypes(t *testing.T) {
+	assert.Empty(t, Match(map[int]int{1: 1}).
+		When(map[string]int{"a": 1}, 1).
+		When(map[int]int{1: 1}, 2).
+		Lint())
+}
+
+func TestLint_RuleSetPriority(t *testing.T) {
+	warnings := NewRuleSet().
+		When(ANY, "any").
+		WhenPriority(1, 42, "answer").
+		When(7, "seven").
+		Lint()
+
+	assert.Equal(t, []int{2}, lintedBranches(warnings))
+}
+
+func lintedBranches(warnings []LintWarning) []int {
+	var branches []int
+	for _, w := range warnings {
+		branches = append(branches, w.Branch)
+	}
+
+	return branches
+}
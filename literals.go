@@ -0,0 +1,173 @@
+package match
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var stringType = reflect.TypeOf("")
+
+// literalDispatch indexes the string literal branches of a RuleSet, so a
+// string value jumps to the first literal branch equal to it instead of
+// comparing every literal. Only the other branches evaluated before that
+// literal are matched, consecutive regexp branches by a single combined regexp.
+type literalDispatch struct {
+	order []int
+	// positions are the positions in order of the first literal branch per string
+	positions map[string]int
+	// steps are the branches which aren't string literals in order
+	steps []dispatchStep
+}
+
+// dispatchStep is a branch at the position in order, or consecutive regexp
+// branches at the positions combined into one regexp, see combineRegexps.
+type dispatchStep struct {
+	positions []int
+	combined  *regexp.Regexp
+	// groups are the submatch indices of the branches in combined
+	groups []int
+}
+
+func newLiteralDispatch(matchItems []matchItem, order []int) *literalDispatch {
+	d := &literalDispatch{order: order, positions: map[string]int{}}
+	var run []int
+	flush := func() {
+		d.steps = append(d.steps, combineRegexps(matchItems, order, run)...)
+		run = nil
+	}
+
+	for pos, index := range order {
+		switch pattern := matchItems[index].pattern.(type) {
+		case string:
+			if _, seen := d.positions[pattern]; !seen {
+				d.positions[pattern] = pos
+			}
+		case *regexp.Regexp:
+			run = append(run, pos)
+		default:
+			flush()
+			d.steps = append(d.steps, dispatchStep{positions: []int{pos}})
+		}
+	}
+	flush()
+
+	return d
+}
+
+// combineRegexps returns the step matching the regexp branches at the
+// positions by one regexp, ^(?:.*?(r1)|.*?(r2)|...). Alternatives are
+// preferred in order, so the first submatch found is of the first branch
+// whose regexp matches anywhere in the string.
+func combineRegexps(matchItems []matchItem, order []int, positions []int) []dispatchStep {
+	if len(positions) < 2 {
+		return separateSteps(positions)
+	}
+
+	step := dispatchStep{positions: positions}
+	alternatives := make([]string, len(positions))
+	group := 1
+	for i, pos := range positions {
+		r := matchItems[order[pos]].pattern.(*regexp.Regexp)
+		alternatives[i] = "(?s:.*?)(" + r.String() + ")"
+		step.groups = append(step.groups, group)
+		group += 1 + r.NumSubexp()
+	}
+
+	combined, err := regexp.Compile("^(?:" + strings.Join(alternatives, "|") + ")")
+	if err != nil {
+		// e.g. the combined regexp exceeds the nesting depth, the branches are
+		// matched one by one then
+		return separateSteps(positions)
+	}
+	step.combined = combined
+
+	return []dispatchStep{step}
+}
+
+// separateSteps returns a step per branch at the positions.
+func separateSteps(positions []int) []dispatchStep {
+	steps := make([]dispatchStep, len(positions))
+	for i, pos := range positions {
+		steps[i] = dispatchStep{positions: []int{pos}}
+	}
+
+	return steps
+}
+
+// canDispatch reports whether string literal branches match only equal
+// strings, so they can be skipped. Middleware must see every branch, while
+// normalizers and registered matchers applying to strings may match other
+// strings.
+func (rs *RuleSet) canDispatch(val interface{}) bool {
+	_, isString := val.(string)
+
+	return isString && rs.cache == nil && len(rs.matcher.middlewares) == 0 &&
+		rs.matcher.state.normalizeString == nil && len(matchersFor(stringType)) == 0
+}
+
+func (rs *RuleSet) findLiteral(d *literalDispatch, matchItems []matchItem, matchFunc MatchFunc, stats *MatcherStats, val interface{}) (matchItem, []MatchItem, bool) {
+	// val is passed boxed, so matching the other branches doesn't allocate
+	target, isLiteral := d.positions[val.(string)]
+	for _, step := range d.steps {
+		if isLiteral && step.positions[0] > target {
+			break
+		}
+
+		var pos int
+		var matchedItems []MatchItem
+		var matched bool
+		if stats == nil {
+			pos, matchedItems, matched = step.match(matchItems, d.order, matchFunc, val)
+		} else {
+			stats.evaluate(matchItems, func() (int, bool) {
+				pos, matchedItems, matched = step.match(matchItems, d.order, matchFunc, val)
+				return d.order[pos], matched
+			})
+		}
+		if matched && (!isLiteral || pos < target) {
+			index := d.order[pos]
+			rs.hit(index)
+			return matchItems[index], matchedItems, true
+		}
+
+		if matched {
+			break
+		}
+	}
+
+	if !isLiteral {
+		return matchItem{}, nil, false
+	}
+
+	index := d.order[target]
+	if stats != nil {
+		stats.evaluate(matchItems, func() (int, bool) { return index, true })
+	}
+	rs.hit(index)
+
+	return matchItems[index], nil, true
+}
+
+// match returns the position of the matched branch of the step.
+func (step dispatchStep) match(matchItems []matchItem, order []int, matchFunc MatchFunc, val interface{}) (int, []MatchItem, bool) {
+	if step.combined == nil {
+		pos := step.positions[0]
+		matchedItems, matched := matchFunc(matchItems[order[pos]].pattern, val)
+		return pos, matchedItems, matched
+	}
+
+	submatches := step.combined.FindStringSubmatchIndex(val.(string))
+	if submatches == nil {
+		return step.positions[0], nil, false
+	}
+
+	for i, group := range step.groups {
+		if submatches[2*group] >= 0 {
+			return step.positions[i], nil, true
+		}
+	}
+
+	panic("match: no alternative of " + strconv.Quote(step.combined.String()) + " matched")
+}
@@ -0,0 +1,121 @@
+package match
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSet_LiteralDispatch(t *testing.T) {
+	rs := NewRuleSet()
+	rs.When(regexp.MustCompile("^admin"), "admin")
+	for i := 0; i < 500; i++ {
+		rs.When(fmt.Sprintf("event-%d", i), i)
+	}
+	rs.When(HasPrefix("event-"), "unknown event")
+	rs.When("event-1", "shadowed")
+
+	_, res := rs.Result("event-42")
+	assert.Equal(t, 42, res)
+
+	_, res = rs.Result("admin.login")
+	assert.Equal(t, "admin", res)
+
+	_, res = rs.Result("event-1000")
+	assert.Equal(t, "unknown event", res)
+
+	isMatched, _ := rs.Result("other")
+	assert.False(t, isMatched)
+
+	isMatched, res = rs.Result(42)
+	assert.False(t, isMatched)
+	assert.Nil(t, res)
+
+	assert.Equal(t, uint64(1), rs.Hits()[43])
+}
+
+func TestRuleSet_LiteralDispatchOrder(t *testing.T) {
+	rs := NewRuleSet().
+		When("a", "literal").
+		WhenPriority(1, HasPrefix("a"), "prefix")
+
+	_, res := rs.Result("a")
+	assert.Equal(t, "prefix", res)
+
+	rs.WhenPriority(2, "a", "high literal")
+	_, res = rs.Result("a")
+	assert.Equal(t, "high literal", res)
+}
+
+func TestRuleSet_LiteralDispatchAdaptive(t *testing.T) {
+	rs := NewRuleSet().Adaptive().When("x", 1).When("y", 2).When(ANY, 3)
+	for i := 0; i < 3; i++ {
+		rs.Result("y")
+	}
+
+	_, res := rs.Result("x")
+	assert.Equal(t, 1, res)
+
+	_, res = rs.Result("z")
+	assert.Equal(t, 3, res)
+}
+
+func TestRuleSet_RegexpDispatch(t *testing.T) {
+	rs := NewRuleSet().
+		When(regexp.MustCompile(`^(\w+)@example\.com$`), "example").
+		When(regexp.MustCompile(`(?i)ADMIN`), "admin").
+		When(regexp.MustCompile(`\d{3}`), "digits").
+		When("root", "root").
+		When(regexp.MustCompile(`o+t`), "not root").
+		When(ANY, "other")
+
+	_, res := rs.Result("admin@example.com")
+	assert.Equal(t, "example", res)
+
+	_, res = rs.Result("sysadmin 123")
+	assert.Equal(t, "admin", res)
+
+	_, res = rs.Result("user 123")
+	assert.Equal(t, "digits", res)
+
+	_, res = rs.Result("root")
+	assert.Equal(t, "root", res)
+
+	_, res = rs.Result("boot")
+	assert.Equal(t, "not root", res)
+
+	_, res = rs.Result("x")
+	assert.Equal(t, "other", res)
+
+	assert.Equal(t, []uint64{1, 1, 1, 1, 1, 1}, rs.Hits())
+
+	// the combined regexp exceeds the nesting depth, so both are matched separately
+	nested := regexp.MustCompile(strings.Repeat("(", 999) + "x" + strings.Repeat(")", 999))
+	rs = NewRuleSet().
+		When(nested, "nested").
+		When(regexp.MustCompile("^hello$"), "hello").
+		When(ANY, "other")
+
+	_, res = rs.Result("hello")
+	assert.Equal(t, "hello", res)
+
+	_, res = rs.Result("x")
+	assert.Equal(t, "nested", res)
+}
+
+func TestRuleSet_LiteralDispatchRegisteredMatchers(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	rs := NewRuleSet().When("a", 1).When("b", 2)
+
+	RegisterMatcherFor(func(pattern interface{}, value temperature) bool { return true })
+	assert.True(t, rs.canDispatch("b"))
+
+	RegisterMatcherFor(func(pattern interface{}, value string) bool { return pattern == "a" && value == "alpha" })
+	assert.False(t, rs.canDispatch("b"))
+
+	_, res := rs.Result("alpha")
+	assert.Equal(t, 1, res)
+}
@@ -0,0 +1,164 @@
+package match
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// MagicSignature is a part of a file signature, the bytes expected at the offset.
+type MagicSignature struct {
+	Offset int
+	Bytes  []byte
+}
+
+var (
+	magicMu         sync.RWMutex
+	magicSignatures = map[string][][]MagicSignature{}
+)
+
+func init() {
+	for _, magic := range []struct {
+		name  string
+		parts []MagicSignature
+	}{
+		{"PNG", []MagicSignature{{0, []byte("\x89PNG\r\n\x1a\n")}}},
+		{"JPEG", []MagicSignature{{0, []byte{0xff, 0xd8, 0xff}}}},
+		{"GIF", []MagicSignature{{0, []byte("GIF87a")}}},
+		{"GIF", []MagicSignature{{0, []byte("GIF89a")}}},
+		{"WEBP", []MagicSignature{{0, []byte("RIFF")}, {8, []byte("WEBP")}}},
+		{"PDF", []MagicSignature{{0, []byte("%PDF-")}}},
+		{"ZIP", []MagicSignature{{0, []byte("PK\x03\x04")}}},
+		{"ZIP", []MagicSignature{{0, []byte("PK\x05\x06")}}},
+		{"GZIP", []MagicSignature{{0, []byte{0x1f, 0x8b}}}},
+		{"ZSTD", []MagicSignature{{0, []byte{0x28, 0xb5, 0x2f, 0xfd}}}},
+		{"BZIP2", []MagicSignature{{0, []byte("BZh")}}},
+		{"XZ", []MagicSignature{{0, []byte{0xfd, '7', 'z', 'X', 'Z', 0}}}},
+		{"7Z", []MagicSignature{{0, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}}}},
+		{"RAR", []MagicSignature{{0, []byte("Rar!\x1a\x07")}}},
+		{"TAR", []MagicSignature{{257, []byte("ustar")}}},
+		{"ELF", []MagicSignature{{0, []byte("\x7fELF")}}},
+		{"PE", []MagicSignature{{0, []byte("MZ")}}},
+		{"MACHO", []MagicSignature{{0, []byte{0xfe, 0xed, 0xfa, 0xce}}}},
+		{"MACHO", []MagicSignature{{0, []byte{0xfe, 0xed, 0xfa, 0xcf}}}},
+		{"MACHO", []MagicSignature{{0, []byte{0xce, 0xfa, 0xed, 0xfe}}}},
+		{"MACHO", []MagicSignature{{0, []byte{0xcf, 0xfa, 0xed, 0xfe}}}},
+		{"WASM", []MagicSignature{{0, []byte("\x00asm")}}},
+		{"SQLITE", []MagicSignature{{0, []byte("SQLite format 3\x00")}}},
+	} {
+		RegisterMagic(magic.name, magic.parts...)
+	}
+}
+
+// RegisterMagic registers a signature of the file type, all the parts must
+// match. A name registered many times matches any of its signatures, e.g.
+// GIF87a and GIF89a are both "GIF". It panics with *PatternError when an
+// offset is negative. It's safe to call concurrently with matching.
+func RegisterMagic(name string, parts ...MagicSignature) {
+	for _, part := range parts {
+		if part.Offset < 0 {
+			panic(newPatternError(Magic(name), fmt.Sprintf("negative offset %d", part.Offset)))
+		}
+	}
+
+	magicMu.Lock()
+	defer magicMu.Unlock()
+
+	magicSignatures[name] = append(magicSignatures[name], parts)
+}
+
+func magicSignaturesOf(name string) ([][]MagicSignature, bool) {
+	magicMu.RLock()
+	defer magicMu.RUnlock()
+
+	signatures, ok := magicSignatures[name]
+	return signatures, ok
+}
+
+type magicPattern struct {
+	name string
+}
+
+// Magic defines the pattern for the file type by the magic bytes at the head
+// of []byte and string values or readers. Built-in names are PNG, JPEG, GIF,
+// WEBP, PDF, ZIP, GZIP, ZSTD, BZIP2, XZ, 7Z, RAR, TAR, ELF, PE, MACHO, WASM,
+// SQLITE, MP4, MATROSKA and WAV, more can be added by RegisterMagic.
+//
+// Readers are not consumed: *bufio.Reader is peeked and io.ReaderAt is read
+// at the offsets, other readers aren't matched, see MatchReader.
+func Magic(name string) interface{} {
+	return magicPattern{name}
+}
+
+func (mp magicPattern) formatPattern() string {
+	return "Magic(" + strconv.Quote(mp.name) + ")"
+}
+
+func (mp magicPattern) headLen() int {
+	signatures, _ := magicSignaturesOf(mp.name)
+	return magicLen(signatures)
+}
+
+func (mp magicPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	signatures, ok := magicSignaturesOf(mp.name)
+	if !ok {
+		panic(newPatternError(mp, fmt.Sprintf("unknown magic %q", mp.name)))
+	}
+
+	head, ok := peekHead(value, magicLen(signatures))
+	if !ok {
+		return nil, false
+	}
+
+	for _, parts := range signatures {
+		if magicMatches(head, parts) {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func magicLen(signatures [][]MagicSignature) int {
+	n := 0
+	for _, parts := range signatures {
+		for _, part := range parts {
+			n = max(n, part.Offset+len(part.Bytes))
+		}
+	}
+
+	return n
+}
+
+func magicMatches(head []byte, parts []MagicSignature) bool {
+	for _, part := range parts {
+		end := part.Offset + len(part.Bytes)
+		if end > len(head) || !bytes.Equal(head[part.Offset:end], part.Bytes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// peekHead returns at most n first bytes of the value without consuming readers.
+func peekHead(value interface{}, n int) ([]byte, bool) {
+	if data, ok := bytesOf(value); ok {
+		return data[:min(n, len(data))], true
+	}
+
+	switch r := value.(type) {
+	case *bufio.Reader:
+		head, err := r.Peek(n)
+		return head, err == nil || len(head) > 0
+	case io.ReaderAt:
+		head := make([]byte, n)
+		read, err := r.ReadAt(head, 0)
+		return head[:read], err == nil || err == io.EOF
+	}
+
+	return nil, false
+}
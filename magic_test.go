@@ -0,0 +1,85 @@
+package match
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fileType(value interface{}) interface{} {
+	_, res := Match(value).
+		When(Magic("PNG"), "png").
+		When(Magic("GIF"), "gif").
+		When(Magic("WEBP"), "webp").
+		When(Magic("ELF"), "elf").
+		When(Magic("TAR"), "tar").
+		When(ANY, "unknown").
+		Result()
+	return res
+}
+
+func TestMagic(t *testing.T) {
+	tar := make([]byte, 512)
+	copy(tar[257:], "ustar")
+
+	assert.Equal(t, "png", fileType([]byte("\x89PNG\r\n\x1a\n....")))
+	assert.Equal(t, "gif", fileType("GIF89a..."))
+	assert.Equal(t, "webp", fileType([]byte("RIFF\x00\x00\x00\x00WEBPVP8 ")))
+	assert.Equal(t, "tar", fileType(tar))
+	assert.Equal(t, "unknown", fileType([]byte("RIFF\x00\x00\x00\x00WAVE")))
+	assert.Equal(t, "unknown", fileType([]byte{}))
+	assert.Equal(t, "unknown", fileType(42))
+}
+
+func TestMagic_Readers(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("\x7fELF\x02\x01"))
+	assert.Equal(t, "elf", fileType(r))
+
+	rest, _ := io.ReadAll(r)
+	assert.Equal(t, []byte("\x7fELF\x02\x01"), rest)
+
+	assert.Equal(t, "gif", fileType(bytes.NewReader([]byte("GIF87a"))))
+	assert.Equal(t, "unknown", fileType(io.MultiReader(strings.NewReader("GIF87a"))))
+}
+
+func TestMagic_Register(t *testing.T) {
+	defer delete(magicSignatures, "CAFE")
+	RegisterMagic("CAFE", MagicSignature{Offset: 2, Bytes: []byte{0xca, 0xfe}})
+
+	isMatched, _ := Match([]byte{0, 0, 0xca, 0xfe}).When(Magic("CAFE"), true).Result()
+	assert.True(t, isMatched)
+
+	_, _, err := Match([]byte{}).When(Magic("NOPE"), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+}
+
+func TestMagic_RegisterNegativeOffset(t *testing.T) {
+	assert.Panics(t, func() { RegisterMagic("BAD", MagicSignature{Offset: -1, Bytes: []byte{1}}) })
+
+	_, ok := magicSignaturesOf("BAD")
+	assert.False(t, ok)
+}
+
+func TestMagic_RegisterConcurrently(t *testing.T) {
+	defer delete(magicSignatures, "LATE")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		RegisterMagic("LATE", MagicSignature{Bytes: []byte("late")})
+	}()
+
+	for i := 0; i < 100; i++ {
+		Match([]byte("GIF87a")).When(Magic("GIF"), true).Result()
+	}
+	wg.Wait()
+
+	isMatched, _ := Match([]byte("late")).When(Magic("LATE"), true).Result()
+	assert.True(t, isMatched)
+}
@@ -12,13 +12,6 @@ type matchItem struct {
 	action  interface{}
 }
 
-// PatternChecker is func for checking pattern.
-type PatternChecker func(pattern interface{}, value interface{}) bool
-
-var (
-	registeredMatchers []PatternChecker
-)
-
 const (
 	// ANY is the pattern which allows any value.
 	ANY matchKey = 0
@@ -26,6 +19,8 @@ const (
 	HEAD matchKey = 1
 	// TAIL is the pattern for end element(s) of slice.
 	TAIL matchKey = 2
+	// ANYSUBTREE is the pattern for any number of child subtrees in Node patterns.
+	ANYSUBTREE matchKey = 3
 )
 
 // MatchItem defines a matched item value.
@@ -34,6 +29,16 @@ type MatchItem struct {
 	valueAsSlice []interface{}
 }
 
+// Value returns the matched value.
+func (mi MatchItem) Value() interface{} {
+	return mi.value
+}
+
+// Slice returns the matched values of HEAD and TAIL patterns.
+func (mi MatchItem) Slice() []interface{} {
+	return mi.valueAsSlice
+}
+
 type oneOfContainer struct {
 	items []interface{}
 }
@@ -43,16 +48,75 @@ func OneOf(items ...interface{}) oneOfContainer {
 	return oneOfContainer{items}
 }
 
+func (container oneOfContainer) formatPattern() string {
+	return "OneOf(" + formatList(container.items) + ")"
+}
+
+func (container oneOfContainer) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	return nil, oneOfContainerPatternMatch(ms, container, value)
+}
+
+// customPattern is implemented by the patterns built by the package, like OneOf or StructOf.
+type customPattern interface {
+	matchValue(ms *matchState, value interface{}) ([]MatchItem, bool)
+}
+
+// Pattern is implemented by pattern types defined outside of the package.
+// Nested patterns should be checked with the given MatchFunc, so they are
+// matched with the options of the Matcher.
+type Pattern interface {
+	MatchValue(value interface{}, match MatchFunc) ([]MatchItem, bool)
+}
+
 // Matcher struct
 type Matcher struct {
-	value      interface{}
-	matchItems []matchItem
+	value       interface{}
+	matchItems  []matchItem
+	middlewares []func(next MatchFunc) MatchFunc
+	// match is the MatchFunc wrapped by the middlewares, see matchFunc
+	match MatchFunc
+	state matchState
+	// site is the construction site recorded for the coverage, see EnableCoverage
+	site string
+	// stats record the cost of the branches, see WithStats
+	stats *MatcherStats
+}
+
+// matchState holds the options of the matcher for the matching process.
+type matchState struct {
+	unexported       bool
+	numericTolerance bool
+	// numericStrings makes strings which are numbers match number patterns
+	numericStrings bool
+	// normalizeString is applied to both sides of string comparisons
+	normalizeString func(string) string
+	// vars are the values bound by Var patterns, see scopeFields
+	vars map[string]interface{}
+	// scratch holds the reused buffers of map matching, see WithScratch
+	scratch *scratchPool
+	// streams are the elements of the drained channels, see streamMatchFunc
+	streams map[interface{}][]interface{}
+	// reflected is the value passed to Match as reflect.Value, it's passed
+	// to the value matchers as is, see RegisterValueMatcher
+	reflected reflect.Value
 }
 
-// Match function takes a value for matching and
+// Match function takes a value for matching and returns the Matcher.
+// A reflect.Value is unwrapped, so integrations which already operate on
+// reflect can pass values as is. A reflect.Value which can't be interfaced,
+// e.g. of an unexported field, matches no pattern.
 func Match(val interface{}) *Matcher {
+	var reflected reflect.Value
+	if rv, ok := val.(reflect.Value); ok {
+		val, reflected = unwrapReflectValue(rv), rv
+	}
+
 	matchItems := []matchItem{}
-	return &Matcher{val, matchItems}
+	matcher := &Matcher{value: val, matchItems: matchItems, site: coverageSite()}
+	matcher.state.reflected = reflected
+	matcher.match = matcher.buildMatchFunc()
+
+	return matcher
 }
 
 // When function adds new pattern for checking matching.
@@ -60,77 +124,224 @@ func Match(val interface{}) *Matcher {
 func (matcher *Matcher) When(val interface{}, fun interface{}) *Matcher {
 	newMatchItem := matchItem{val, fun}
 	matcher.matchItems = append(matcher.matchItems, newMatchItem)
+	if matcher.site != "" {
+		coverBranch(matcher.site, len(matcher.matchItems)-1, val)
+	}
 
 	return matcher
 }
 
-// RegisterMatcher register custom pattern.
-func RegisterMatcher(pattern PatternChecker) {
-	registeredMatchers = append(registeredMatchers, pattern)
+// WithUnexported allows struct patterns (StructOf, Fields) to match unexported
+// fields. The fields are read via reflect and unsafe from a copy of the struct,
+// so it bypasses the encapsulation of the package owning the type and ties
+// the patterns to its implementation details. It's off by default and meant
+// mostly for tests of internal domain types.
+func (matcher *Matcher) WithUnexported() *Matcher {
+	matcher.state.unexported = true
+
+	return matcher
 }
 
-// Result returns the result value of matching process.
+// Result returns the result value of matching process. It doesn't allocate
+// when the branches are scalar literals and the actions aren't funcs.
 func (matcher *Matcher) Result() (bool, interface{}) {
-	for _, mi := range matcher.matchItems {
-		matchedItems, matched := matchValue(mi.pattern, matcher.value)
+	matchFunc := matcher.matchFunc()
+	if streamFunc, ok := matcher.streamMatchFunc(matcher.value); ok {
+		matchFunc = streamFunc
+	}
+	for i, mi := range matcher.matchItems {
+		matchedItems, matched := matcher.stats.matchBranch(matcher.matchItems, i, matchFunc, matcher.value)
 		if matched {
-			miActionType := reflect.TypeOf(mi.action)
-			if miActionType.Kind() == reflect.Func {
-				numberOfArgs := miActionType.NumIn()
-				lenMatchedItems := len(matchedItems)
-				if numberOfArgs > lenMatchedItems {
-					for i := lenMatchedItems; i < numberOfArgs; i++ {
-						matchedItems = append(matchedItems, MatchItem{value: nil})
-					}
-				} else if lenMatchedItems > numberOfArgs {
-					matchedItems = matchedItems[:numberOfArgs]
-				}
-
-				var params []reflect.Value
-				for i := 0; i < len(matchedItems); i++ {
-					params = append(params, reflect.ValueOf(matchedItems[i]))
-				}
-
-				funcRes := reflect.ValueOf(mi.action).Call(params)
-				if (len(funcRes)) > 0 {
-					return true, funcRes[0].Interface()
-				}
-
-				return true, nil
+			matcher.hit(i)
+			return true, callAction(mi.action, matchedItems)
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *Matcher) hit(index int) {
+	if matcher.site != "" {
+		coverHit(matcher.site, index)
+	}
+}
+
+func (matcher *Matcher) formatPattern() string {
+	return "Matcher(" + formatBranches(matcher.matchItems) + ")"
+}
+
+// matchValue makes Matcher usable as a pattern, it matches if any branch
+// matches. The value of the Matcher and actions of the branches are not used.
+func (matcher *Matcher) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if matcher.state.reflected.IsValid() {
+		// the value of the Matcher isn't matched, so neither is its reflect.Value
+		pattern := *matcher
+		pattern.state.reflected = reflect.Value{}
+		pattern.match = pattern.buildMatchFunc()
+
+		return pattern.matchValue(ms, value)
+	}
+
+	matchFunc := matcher.matchFunc()
+	if streamFunc, ok := matcher.streamMatchFunc(value); ok {
+		matchFunc = streamFunc
+	}
+	for i := range matcher.matchItems {
+		if matchedItems, matched := matcher.stats.matchBranch(matcher.matchItems, i, matchFunc, value); matched {
+			matcher.hit(i)
+			return matchedItems, true
+		}
+	}
+
+	return nil, false
+}
+
+// ResultE returns the result value of matching process like Result does,
+// but invalid pattern usage is returned as *PatternError instead of panicking.
+// When no pattern matches, the error is *NoMatchError (errors.Is ErrNoMatch).
+func (matcher *Matcher) ResultE() (bool, interface{}, error) {
+	return resultE(matcher.value, matcher.matchItems, matcher.Result)
+}
+
+func resultE(value interface{}, matchItems []matchItem, result func() (bool, interface{})) (matched bool, res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			patternErr, ok := r.(*PatternError)
+			if !ok {
+				panic(r)
 			}
 
-			return true, mi.action
+			matched, res, err = false, nil, patternErr
 		}
+	}()
+
+	matched, res = result()
+	if !matched {
+		return false, nil, newNoMatchError(value, matchItems)
 	}
 
-	return false, nil
+	return true, res, nil
 }
 
-func matchValue(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+func callAction(action interface{}, matchedItems []MatchItem) interface{} {
+	actionType := reflect.TypeOf(action)
+	if actionType.Kind() != reflect.Func {
+		return action
+	}
+
+	numberOfArgs := actionType.NumIn()
+	lenMatchedItems := len(matchedItems)
+	if numberOfArgs > lenMatchedItems {
+		for i := lenMatchedItems; i < numberOfArgs; i++ {
+			matchedItems = append(matchedItems, MatchItem{value: nil})
+		}
+	} else if lenMatchedItems > numberOfArgs {
+		matchedItems = matchedItems[:numberOfArgs]
+	}
+
+	var params []reflect.Value
+	for i := 0; i < len(matchedItems); i++ {
+		params = append(params, reflect.ValueOf(matchedItems[i]))
+	}
+
+	funcRes := reflect.ValueOf(action).Call(params)
+	if (len(funcRes)) > 0 {
+		return funcRes[0].Interface()
+	}
+
+	return nil
+}
+
+func matchValue(ms *matchState, pattern interface{}, value interface{}) ([]MatchItem, bool) {
+	reflected := ms.reflected
+	if reflected.IsValid() {
+		if !reflected.CanInterface() {
+			return nil, false
+		}
+
+		ms = ms.withoutReflected()
+	}
+
+	matchedItems, matched := matchValueAsIs(ms, pattern, value, reflected)
+	if !matched {
+		// sql.Null* and other driver.Valuer values are matched by their inner value
+		if inner, ok := sqlValue(value); ok {
+			return matchValue(ms, pattern, inner)
+		}
+
+		if number, ok := parseNumericString(ms, value); ok {
+			return matchValueAsIs(ms, pattern, number, reflect.Value{})
+		}
+
+		// values of url.Values keys are matched by the first value, see MatchValues
+		if values, ok := value.(multiValue); ok && len(values) > 0 {
+			return matchValue(ms, pattern, values[0])
+		}
+	}
+
+	return matchedItems, matched
+}
+
+// matchValueAsIs matches the value without unwrapping it. The reflected value
+// is passed to the value matchers when it's valid, see matchState.reflected.
+func matchValueAsIs(ms *matchState, pattern interface{}, value interface{}, reflected reflect.Value) ([]MatchItem, bool) {
 	if pattern == ANY {
 		return nil, true
 	}
 
-	for _, registerMatcher := range registeredMatchers {
-		if registerMatcher(pattern, value) {
-			return nil, true
-		}
+	checkResult, err := checkRegisteredMatchers(pattern, value, reflected)
+	if err != nil {
+		panic(&PatternError{Pattern: pattern, Err: err})
 	}
 
-	// Handle the case when value has simple type
-	simpleTypes := []reflect.Kind{reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16,
-		reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
-		reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64,
-		reflect.Complex64, reflect.Complex128,
+	switch checkResult {
+	case Matched:
+		return nil, true
+	case NotMatched:
+		return nil, false
+	}
+
+	if p, ok := pattern.(customPattern); ok {
+		return p.matchValue(ms, value)
 	}
 
+	if p, ok := pattern.(Pattern); ok {
+		return p.MatchValue(value, func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+			return matchValue(ms, pattern, value)
+		})
+	}
+
+	if value == nil || pattern == nil {
+		return nil, value == nil && pattern == nil
+	}
+
+	// Handle the case when pattern is an arbitrary-precision number, it's compared by value
+	if isBigNumber(pattern) {
+		cmp, ok := compareBig(value, pattern)
+		return nil, ok && cmp == 0
+	}
+
+	// An arbitrary-precision value is compared by value to number patterns
+	if isBigNumber(value) {
+		if cmp, ok := compareBig(value, pattern); ok {
+			return nil, cmp == 0
+		}
+	}
+
+	// Handle the case when value has simple type
 	valueKind := reflect.TypeOf(value).Kind()
-	valueIsSimpleType := containsKind(simpleTypes, valueKind)
+	valueIsSimpleType := isSimpleKind(valueKind)
 
 	if (valueIsSimpleType) && value == pattern {
 		return nil, true
 	}
 
+	if valueIsSimpleType && ms.numericTolerance {
+		if cmp, ok := compareNumbers(value, pattern); ok && cmp == 0 {
+			return nil, true
+		}
+	}
+
 	// Handle the case when value has slice or array type
 	patternType := reflect.TypeOf(pattern)
 	patternKind := patternType.Kind()
@@ -138,7 +349,7 @@ func matchValue(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 	if (valueKind == reflect.Slice || valueKind == reflect.Array) &&
 		patternKind == reflect.Slice {
 
-		matchedItems, isMatched := matchSlice(pattern, value)
+		matchedItems, isMatched := matchSlice(ms, pattern, value)
 		if isMatched {
 			return matchedItems, isMatched
 		}
@@ -156,10 +367,15 @@ func matchValue(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 		}
 	}
 
+	// Handle the case when value is sync.Map or other map types implementing Iterable
+	if iterable, ok := value.(Iterable); ok && patternKind == reflect.Map {
+		return nil, matchMap(ms, pattern, iterableMap(iterable))
+	}
+
 	// Handle the case when value has map type
 	if valueKind == reflect.Map &&
 		patternKind == reflect.Map &&
-		matchMap(pattern, value) {
+		matchMap(ms, pattern, value) {
 
 		return nil, true
 	}
@@ -171,9 +387,20 @@ func matchValue(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 			return nil, true
 		}
 
+		if patternKind == reflect.String && ms.normalizeString != nil && patternType == reflect.TypeOf(value) {
+			normalizedPattern := ms.normalizeString(reflect.ValueOf(pattern).String())
+			if normalizedPattern == ms.normalizeString(reflect.ValueOf(value).String()) {
+				return nil, true
+			}
+		}
+
 		// When pattern is regexp
 		reg, ok := pattern.(*regexp.Regexp)
 		if ok {
+			if ms.normalizeString != nil {
+				value = ms.normalizeString(reflect.ValueOf(value).String())
+			}
+
 			if matchRegexp(reg, value) {
 				return nil, true
 			}
@@ -187,10 +414,14 @@ func matchValue(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 	return nil, false
 }
 
-func matchSlice(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+func matchSlice(ms *matchState, pattern interface{}, value interface{}) ([]MatchItem, bool) {
 	patternSlice := reflect.ValueOf(pattern)
 	patternSliceLen := patternSlice.Len()
 
+	if data, ok := value.([]byte); ok && hasUintPattern(patternSlice) {
+		return matchBytesSlice(ms, patternSlice, data)
+	}
+
 	valueSlice := reflect.ValueOf(value)
 	valueSliceLen := valueSlice.Len()
 
@@ -204,20 +435,21 @@ func matchSlice(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 		patternSliceInterface := patternSliceVal.Interface()
 
 		for i := 0; i < valueSliceLen-patternSliceLen+1; i++ {
-			matchedItems, isMatched := matchSubSlice(patternSliceInterface, valueSlice.Slice(i, valueSliceLen).Interface())
-			resMatchedItems := append([]MatchItem{{valueAsSlice: sliceValueToSliceOfInterfaces(valueSlice.Slice(0, i))}}, matchedItems...)
+			matchedItems, isMatched := tryAlternative(ms, func() ([]MatchItem, bool) {
+				return matchSubSlice(ms, patternSliceInterface, valueSlice.Slice(i, valueSliceLen).Interface())
+			})
 			if isMatched {
-				return resMatchedItems, true
+				return append([]MatchItem{{valueAsSlice: sliceValueToSliceOfInterfaces(valueSlice.Slice(0, i))}}, matchedItems...), true
 			}
 		}
 
 		return nil, false
 	}
 
-	return matchSubSlice(pattern, value)
+	return matchSubSlice(ms, pattern, value)
 }
 
-func matchSubSlice(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+func matchSubSlice(ms *matchState, pattern interface{}, value interface{}) ([]MatchItem, bool) {
 	patternSlice := reflect.ValueOf(pattern)
 	valueSlice := reflect.ValueOf(value)
 
@@ -246,27 +478,32 @@ func matchSubSlice(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 		currValue := valueSlice.Index(currValueIndex).Interface()
 
 		if currPattern == HEAD {
-			panic("HEAD can only be in first position of a pattern.")
+			panic(newPatternError(pattern, "HEAD can only be in first position of a pattern."))
 		} else if currPattern == TAIL {
 			if patternSliceMaxIndex > i {
-				panic("TAIL must me in last position of the pattern.")
+				panic(newPatternError(pattern, "TAIL must me in last position of the pattern."))
 			} else {
 				matchedItems = append(matchedItems, MatchItem{valueAsSlice: sliceValueToSliceOfInterfaces(valueSlice.Slice(i, valueSliceMaxIndex+1))})
 				break
 			}
-		} else if reflect.TypeOf(currPattern).AssignableTo(oneOfContainerType) {
-			if !oneOfContainerPatternMatch(currPattern, currValue) {
+		} else if reflect.TypeOf(currPattern) == oneOfContainerType {
+			if !oneOfContainerPatternMatch(ms, currPattern, currValue) {
 				return matchedItems, false
 			}
 		} else if currPattern == ANY {
 			matchedItems = append(matchedItems, MatchItem{value: currValue})
 			continue
 		} else {
-			isMatched := matchValueBool(currPattern, currValue)
+			currMatchedItems, isMatched := matchValue(ms, currPattern, currValue)
 
 			if !isMatched {
 				return matchedItems, false
 			}
+
+			// values bound by the patterns of the package, like TimeLayout, are passed to the action
+			if isBindingPattern(currPattern) {
+				matchedItems = append(matchedItems, currMatchedItems...)
+			}
 		}
 	}
 
@@ -282,6 +519,23 @@ func sliceValueToSliceOfInterfaces(val reflect.Value) []interface{} {
 	return res
 }
 
+// withoutReflected returns the state for the values nested in the value passed
+// to Match as reflect.Value, they are reflected as usual.
+func (ms *matchState) withoutReflected() *matchState {
+	nested := *ms
+	nested.reflected = reflect.Value{}
+
+	return &nested
+}
+
+func unwrapReflectValue(val reflect.Value) interface{} {
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+
+	return val.Interface()
+}
+
 func matchStruct(patternType reflect.Type, value interface{}) bool {
 	if patternType.AssignableTo(reflect.TypeOf(value)) {
 		return true
@@ -290,42 +544,65 @@ func matchStruct(patternType reflect.Type, value interface{}) bool {
 	return false
 }
 
-func matchMap(pattern interface{}, value interface{}) bool {
+// matchMap checks that every key of the pattern map is in the value map and
+// its value matches. Keys are equal when they are equal as interface{}, so
+// they must have the same dynamic type.
+func matchMap(ms *matchState, pattern interface{}, value interface{}) bool {
+	if matched, ok := matchStringMap(ms, pattern, value); ok {
+		return matched
+	}
+
 	patternMap := reflect.ValueOf(pattern)
 	valueMap := reflect.ValueOf(value)
+	keyType := valueMap.Type().Key()
 
-	stillUsablePatternKeys := patternMap.MapKeys()
-	stillUsableValueKeys := valueMap.MapKeys()
-	oneOfContainerType := reflect.TypeOf(oneOfContainer{})
+	buf := ms.scratch.get()
+	defer ms.scratch.put(buf)
 
-	for _, pKey := range patternMap.MapKeys() {
-		if !containsValue(stillUsablePatternKeys, pKey) {
-			continue
+	buf.iter.Reset(patternMap)
+	for buf.iter.Next() {
+		pKey, pVal := buf.entry(patternMap.Type())
+		vVal, ok := mapIndex(valueMap, keyType, pKey)
+		if !ok {
+			return false
 		}
-		pVal := patternMap.MapIndex(pKey)
-		matchedLeftAndRight := false
 
-		for _, vKey := range valueMap.MapKeys() {
-			if !containsValue(stillUsableValueKeys, vKey) || !containsValue(stillUsablePatternKeys, pKey) {
-				continue
-			}
+		if !matchMapValue(ms, pVal.Interface(), vVal.Interface()) {
+			return false
+		}
+	}
 
-			vVal := valueMap.MapIndex(vKey)
-			keyMatched := pKey.Interface() == vKey.Interface()
-			if keyMatched {
-				pValInterface := pVal.Interface()
-				vValInterface := vVal.Interface()
-				valueMatched := pValInterface == ANY || matchValueBool(pValInterface, vValInterface) ||
-					(reflect.TypeOf(pValInterface).AssignableTo(oneOfContainerType) && oneOfContainerPatternMatch(pValInterface, vValInterface))
-				if valueMatched {
-					matchedLeftAndRight = true
-					removeValue(stillUsablePatternKeys, pKey)
-					removeValue(stillUsableValueKeys, vKey)
-				}
-			}
+	return true
+}
+
+// matchStringMap is the fast path of matchMap for map[string]interface{} and
+// map[string]string, which dominate JSON and config values. They are iterated
+// directly instead of by reflect, ok is false for other maps.
+func matchStringMap(ms *matchState, pattern interface{}, value interface{}) (matched bool, ok bool) {
+	switch p := pattern.(type) {
+	case map[string]interface{}:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			return matchStringMapOf(ms, p, v), true
+		case map[string]string:
+			return matchStringMapOf(ms, p, v), true
+		}
+	case map[string]string:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			return matchStringMapOf(ms, p, v), true
+		case map[string]string:
+			return matchStringMapOf(ms, p, v), true
 		}
+	}
+
+	return false, false
+}
 
-		if !matchedLeftAndRight {
+func matchStringMapOf[P any, V any](ms *matchState, pattern map[string]P, value map[string]V) bool {
+	for key, pVal := range pattern {
+		vVal, found := value[key]
+		if !found || !matchMapValue(ms, pVal, vVal) {
 			return false
 		}
 	}
@@ -333,15 +610,56 @@ func matchMap(pattern interface{}, value interface{}) bool {
 	return true
 }
 
-func matchValueBool(pattern interface{}, value interface{}) bool {
-	_, res := matchValue(pattern, value)
+func matchMapValue(ms *matchState, pattern interface{}, value interface{}) bool {
+	return pattern == ANY || matchValueBool(ms, pattern, value) ||
+		(reflect.TypeOf(pattern) == reflect.TypeOf(oneOfContainer{}) && oneOfContainerPatternMatch(ms, pattern, value))
+}
+
+// mapIndex returns the value of the map for the key of a pattern map, the
+// key is found only when its dynamic type is the key type of the map, or
+// implements it.
+func mapIndex(valueMap reflect.Value, keyType reflect.Type, key reflect.Value) (reflect.Value, bool) {
+	if key.Kind() == reflect.Interface {
+		key = key.Elem()
+	}
+
+	switch {
+	case !key.IsValid():
+		if keyType.Kind() != reflect.Interface {
+			return reflect.Value{}, false
+		}
+		key = reflect.Zero(keyType)
+	case keyType.Kind() == reflect.Interface && !key.Type().Implements(keyType):
+		return reflect.Value{}, false
+	case keyType.Kind() != reflect.Interface && key.Type() != keyType:
+		return reflect.Value{}, false
+	}
+
+	val := valueMap.MapIndex(key)
+	return val, val.IsValid()
+}
+
+func isBindingPattern(pattern interface{}) bool {
+	switch pattern.(type) {
+	case customPattern, Pattern:
+		return true
+	}
+
+	return false
+}
+
+func matchValueBool(ms *matchState, pattern interface{}, value interface{}) bool {
+	_, res := matchValue(ms, pattern, value)
 	return res
 }
 
-func oneOfContainerPatternMatch(oneOfPattern interface{}, value interface{}) bool {
+func oneOfContainerPatternMatch(ms *matchState, oneOfPattern interface{}, value interface{}) bool {
 	oneOfContainerPatternInstance := oneOfPattern.(oneOfContainer)
 	for _, item := range oneOfContainerPatternInstance.items {
-		if matchValueBool(item, value) {
+		_, matched := tryAlternative(ms, func() ([]MatchItem, bool) {
+			return matchValue(ms, item, value)
+		})
+		if matched {
 			return true
 		}
 	}
@@ -370,37 +688,15 @@ func max(a, b int) int {
 	return a
 }
 
-func removeValue(vals []reflect.Value, val reflect.Value) []reflect.Value {
-	indexOf := -1
-	for index, v := range vals {
-		if val.Interface() == v.Interface() {
-			indexOf = index
-			break
-		}
-	}
-
-	vals[indexOf] = vals[len(vals)-1]
-	vals = vals[:len(vals)-1]
-
-	return vals
-}
-
-func containsValue(vals []reflect.Value, val reflect.Value) bool {
-	valInterface := val.Interface()
-	for _, v := range vals {
-		if valInterface == v.Interface() {
-			return true
-		}
-	}
-
-	return false
-}
-
-func containsKind(vals []reflect.Kind, val reflect.Kind) bool {
-	for _, v := range vals {
-		if val == v {
-			return true
-		}
+// isSimpleKind reports whether values of the kind are compared by ==, it's a
+// switch rather than a slice of kinds so the hot path doesn't allocate.
+func isSimpleKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
 	}
 
 	return false
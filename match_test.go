@@ -1,8 +1,11 @@
 package match
 
 import (
+	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -159,6 +162,16 @@ func TestMatch_SliceWithMatchedItems(t *testing.T) {
 	assert.Equal(5, convertedRes[1][1].(int))
 }
 
+func TestMatch_TimeLayoutBindsInSlice(t *testing.T) {
+	_, res := Match([]string{"2024-03-01", "x"}).
+		When([]interface{}{TimeLayout("2006-01-02"), ANY}, func(date MatchItem, rest MatchItem) []interface{} {
+			return []interface{}{date.Value().(time.Time).Month(), rest.Value()}
+		}).
+		Result()
+
+	assert.Equal(t, []interface{}{time.March, "x"}, res)
+}
+
 func TestMatch_SliceNotMatchWithHeadAndWrongPatternLater(t *testing.T) {
 	isMatched, _ := Match([]interface{}{1, 2, 3, 4, 5}).
 		When([]interface{}{HEAD, 10, 11}, true).
@@ -348,6 +361,35 @@ func TestMatch_MapWithInnerSlice(t *testing.T) {
 	assert.True(t, isMatched)
 }
 
+func TestMatch_StringMaps(t *testing.T) {
+	config := map[string]string{"env": "prod", "region": "eu-west-1"}
+	doc := map[string]interface{}{"env": "prod", "replicas": 3}
+
+	for _, c := range []struct {
+		value    interface{}
+		pattern  interface{}
+		expected bool
+	}{
+		{config, map[string]string{"env": "prod"}, true},
+		{config, map[string]string{"env": "dev"}, false},
+		{config, map[string]interface{}{"env": ANY, "region": HasPrefix("eu-")}, true},
+		{config, map[string]interface{}{"zone": ANY}, false},
+		{doc, map[string]string{"env": "prod"}, true},
+		{doc, map[string]interface{}{"replicas": OneOf(1, 3)}, true},
+		{doc, map[string]interface{}{"replicas": "3"}, false},
+		{doc, map[string]interface{}{}, true},
+	} {
+		isMatched, _ := Match(c.value).When(c.pattern, true).Result()
+		assert.Equal(t, c.expected, isMatched, c.pattern)
+	}
+
+	// registered matchers of other tests are checked for every value
+	isolateRegisteredMatchers(t)
+
+	matcher := Match(doc).When(map[string]interface{}{"env": "prod", "replicas": ANY}, true)
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { matcher.Result() }))
+}
+
 func TestMatch_String(t *testing.T) {
 	isMatched, _ := Match("gophergopher").
 		When("gophergopher", true).
@@ -365,6 +407,7 @@ func TestMatch_Regexp(t *testing.T) {
 }
 
 func TestMatch_RegisterPattern(t *testing.T) {
+	isolateRegisteredMatchers(t)
 	myMagicChecker := func(pattern interface{}, value interface{}) bool {
 
 		if pattern == 12345 {
@@ -450,3 +493,120 @@ func TestMatch_StructDifferentTypeNotMatch(t *testing.T) {
 
 	assert.False(t, isMatched)
 }
+
+func TestMatch_ReflectValue(t *testing.T) {
+	isMatched, _ := Match(reflect.ValueOf(42)).
+		When(42, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_ReflectValueSlice(t *testing.T) {
+	isMatched, _ := Match(reflect.ValueOf([]int{1, 2, 3})).
+		When([]interface{}{HEAD, 3}, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_ReflectValueUnexported(t *testing.T) {
+	field := reflect.ValueOf(struct{ secret interface{} }{}).Field(0)
+
+	isMatched, _ := Match(field).
+		When(nil, true).
+		When(ANY, true).
+		Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = NewRuleSet().When(nil, true).Result(field)
+	assert.False(t, isMatched)
+}
+
+func TestMatch_RegisterValuePatternReflectValue(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	RegisterValueMatcher(func(pattern interface{}, value reflect.Value) bool {
+		return pattern == "settable" && value.CanSet()
+	})
+
+	temperatures := []float64{21.5}
+	isMatched, _ := Match(reflect.ValueOf(temperatures).Index(0)).
+		When("settable", true).
+		Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(reflect.ValueOf(temperatures)).
+		When([]interface{}{"settable"}, true).
+		Result()
+	assert.False(t, isMatched)
+}
+
+func TestMatch_RegisterValuePattern(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	type celsius float64
+	RegisterValueMatcher(func(pattern interface{}, value reflect.Value) bool {
+		return pattern == "freezing" && value.Kind() == reflect.Float64 && value.Float() <= 0
+	})
+
+	isMatched, _ := Match(celsius(-5)).
+		When("freezing", true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+type temperature float64
+
+func TestMatch_RegisterTypedPattern(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	calls := 0
+	RegisterMatcherFor(func(pattern interface{}, value temperature) bool {
+		calls++
+		return pattern == "hot" && value > 30
+	})
+
+	isMatched, _ := Match(temperature(35)).
+		When("hot", true).
+		Result()
+
+	assert.True(t, isMatched)
+	assert.Equal(t, 1, calls)
+
+	Match(35.0).
+		When("hot", true).
+		Result()
+
+	assert.Equal(t, 1, calls)
+}
+
+type fahrenheit float64
+
+func (f fahrenheit) String() string { return "fahrenheit" }
+
+func TestMatch_RegisterTypedPatternForInterface(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	RegisterMatcherFor(func(pattern interface{}, value fmt.Stringer) bool {
+		return pattern == "stringer" && value.String() == "fahrenheit"
+	})
+
+	isMatched, _ := Match(fahrenheit(100)).
+		When("stringer", true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_ScalarResultDoesNotAllocate(t *testing.T) {
+	// registered matchers of other tests are checked for every branch
+	isolateRegisteredMatchers(t)
+
+	var value, miss interface{} = 1000, uint8(7)
+	matcher := Match(value).When(1, "one").When(2.5, 2).When(true, 3).When("x", 4).When(1000, "thousand")
+	rs := NewRuleSet().When(1, "one").When(2.5, 2).When(true, 3).When("x", 4).When(1000, "thousand")
+	str := interface{}("y")
+
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { matcher.Result() }))
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { rs.Result(value) }))
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { rs.Result(miss) }))
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { rs.Result(str) }))
+}
@@ -0,0 +1,104 @@
+// Package matchast provides patterns over go/ast nodes, so static analysis
+// tools can write declarative AST queries with the combinators of the match
+// package, e.g.
+//
+//	matchast.Node[*ast.CallExpr](match.Fields{
+//		"Fun":  matchast.Selector("fmt", "Println"),
+//		"Args": []interface{}{match.HEAD},
+//	})
+package matchast
+
+import (
+	"go/ast"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+)
+
+// Kind defines the pattern for AST nodes of the type T, e.g. Kind[*ast.FuncDecl]().
+func Kind[T ast.Node]() match.Pattern {
+	return nodePattern[T]{}
+}
+
+// Node defines the pattern for AST nodes of the type T with patterns for their
+// fields. Fields without a pattern match any value.
+func Node[T ast.Node](fields match.Fields) match.Pattern {
+	return nodePattern[T]{fields}
+}
+
+type nodePattern[T ast.Node] struct {
+	fields match.Fields
+}
+
+func (np nodePattern[T]) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	node, ok := value.(T)
+	if !ok {
+		return nil, false
+	}
+
+	if len(np.fields) == 0 {
+		return nil, true
+	}
+
+	return matchFunc(np.fields, node)
+}
+
+// Ident defines the pattern for identifiers with the name matching the pattern.
+func Ident(name interface{}) match.Pattern {
+	return Node[*ast.Ident](match.Fields{"Name": name})
+}
+
+// Selector defines the pattern for selector expressions like fmt.Println
+// where x is the identifier on the left side.
+func Selector(x interface{}, sel interface{}) match.Pattern {
+	return Node[*ast.SelectorExpr](match.Fields{"X": Ident(x), "Sel": Ident(sel)})
+}
+
+// Contains defines the pattern for AST nodes which have a node matching the
+// pattern among their descendants (or are one themselves).
+func Contains(pattern interface{}) match.Pattern {
+	return containsPattern{pattern}
+}
+
+type containsPattern struct {
+	pattern interface{}
+}
+
+func (cp containsPattern) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	root, ok := value.(ast.Node)
+	if !ok {
+		return nil, false
+	}
+
+	found := false
+	ast.Inspect(root, func(node ast.Node) bool {
+		if found || node == nil {
+			return false
+		}
+
+		_, found = matchFunc(cp.pattern, node)
+		return !found
+	})
+
+	return nil, found
+}
+
+// Find returns the nodes under the root (including it) which match the
+// pattern, in depth-first order.
+func Find(root ast.Node, pattern interface{}) []ast.Node {
+	rules := match.NewRuleSet().When(pattern, true)
+
+	var res []ast.Node
+	ast.Inspect(root, func(node ast.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		if isMatched, _ := rules.Result(node); isMatched {
+			res = append(res, node)
+		}
+
+		return true
+	})
+
+	return res
+}
@@ -0,0 +1,65 @@
+package matchast
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+)
+
+const source = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+	fmt.Printf("%d\n", 42)
+}
+
+func helper() {}
+`
+
+func parse(t *testing.T) *ast.File {
+	file, err := parser.ParseFile(token.NewFileSet(), "main.go", source, 0)
+	assert.NoError(t, err)
+
+	return file
+}
+
+func TestFind(t *testing.T) {
+	calls := Find(parse(t), Node[*ast.CallExpr](match.Fields{
+		"Fun":  Selector("fmt", regexp.MustCompile("^Print")),
+		"Args": []interface{}{Node[*ast.BasicLit](match.Fields{"Kind": token.STRING}), match.TAIL},
+	}))
+
+	assert.Len(t, calls, 2)
+}
+
+func TestFindWithArguments(t *testing.T) {
+	calls := Find(parse(t), Node[*ast.CallExpr](match.Fields{
+		"Args": []interface{}{
+			Node[*ast.BasicLit](match.Fields{"Kind": token.STRING}),
+			Node[*ast.BasicLit](match.Fields{"Kind": token.INT, "Value": "42"}),
+		},
+	}))
+
+	assert.Len(t, calls, 1)
+}
+
+func TestKindAndContains(t *testing.T) {
+	var kinds []interface{}
+	for _, decl := range parse(t).Decls {
+		_, kind := match.Match(decl).
+			When(Kind[*ast.GenDecl](), "gen").
+			When(Node[*ast.FuncDecl](match.Fields{"Name": Ident("main"), "Body": Contains(Selector("fmt", match.ANY))}), "main").
+			When(Kind[*ast.FuncDecl](), "func").
+			Result()
+		kinds = append(kinds, kind)
+	}
+
+	assert.Equal(t, []interface{}{"gen", "main", "func"}, kinds)
+}
@@ -0,0 +1,69 @@
+// Package matchgonum provides patterns for the matrices of gonum.org/v1/gonum/mat.
+package matchgonum
+
+import (
+	"fmt"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Register registers the matcher which makes mat.Matrix patterns match
+// matrices of the same dimensions whose elements are equal within the
+// tolerance, see mat.EqualApprox. It should be called once, at init.
+func Register(tolerance float64) {
+	match.RegisterMatcherFor[mat.Matrix](func(pattern interface{}, value mat.Matrix) bool {
+		m, ok := pattern.(mat.Matrix)
+		return ok && mat.EqualApprox(m, value, tolerance)
+	})
+}
+
+// Dims defines the pattern for matrices with the numbers of rows and columns
+// matching the patterns, e.g. Dims(match.ANY, 3).
+func Dims(rows interface{}, cols interface{}) match.Pattern {
+	return dimsPattern{rows, cols}
+}
+
+type dimsPattern struct {
+	rows, cols interface{}
+}
+
+func (dp dimsPattern) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	m, ok := value.(mat.Matrix)
+	if !ok {
+		return nil, false
+	}
+
+	r, c := m.Dims()
+	if _, matched := matchFunc(dp.rows, r); !matched {
+		return nil, false
+	}
+
+	_, matched := matchFunc(dp.cols, c)
+	return nil, matched
+}
+
+func (dp dimsPattern) String() string {
+	return "Dims(" + match.FormatPattern(dp.rows) + ", " + match.FormatPattern(dp.cols) + ")"
+}
+
+// ApproxEqual defines the pattern for matrices of the dimensions of m whose
+// elements are equal to the ones of m within the tolerance.
+func ApproxEqual(m mat.Matrix, tolerance float64) match.Pattern {
+	return approxPattern{m, tolerance}
+}
+
+type approxPattern struct {
+	m         mat.Matrix
+	tolerance float64
+}
+
+func (ap approxPattern) MatchValue(value interface{}, _ match.MatchFunc) ([]match.MatchItem, bool) {
+	m, ok := value.(mat.Matrix)
+	return nil, ok && mat.EqualApprox(ap.m, m, ap.tolerance)
+}
+
+func (ap approxPattern) String() string {
+	r, c := ap.m.Dims()
+	return fmt.Sprintf("ApproxEqual(%dx%d matrix, %g)", r, c, ap.tolerance)
+}
@@ -0,0 +1,52 @@
+package matchgonum
+
+import (
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDims(t *testing.T) {
+	classify := func(m mat.Matrix) interface{} {
+		_, res := match.Match(m).
+			When(Dims(1, match.ANY), "row").
+			When(Dims(match.Between(2, 3), 3), "small").
+			When(match.ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "row", classify(mat.NewDense(1, 4, nil)))
+	assert.Equal(t, "small", classify(mat.NewDense(3, 3, nil).T()))
+	assert.Equal(t, "other", classify(mat.NewVecDense(3, nil)))
+}
+
+func TestApproxEqual(t *testing.T) {
+	identity := mat.NewDiagDense(2, []float64{1, 1})
+	m := mat.NewDense(2, 2, []float64{1.0000001, 0, 0, 0.9999999})
+
+	isMatched, _ := match.Match(m).When(ApproxEqual(identity, 1e-6), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = match.Match(m).When(ApproxEqual(identity, 1e-9), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = match.Match(mat.NewDense(1, 2, nil)).When(ApproxEqual(identity, 1), true).Result()
+	assert.False(t, isMatched)
+
+	assert.Equal(t, "ApproxEqual(2x2 matrix, 1e-06)", match.FormatPattern(ApproxEqual(identity, 1e-6)))
+}
+
+func TestRegister(t *testing.T) {
+	Register(1e-6)
+
+	rotation := mat.NewDense(2, 2, []float64{0, -1, 1, 0})
+	_, res := match.Match(mat.NewDense(2, 2, []float64{0, -1, 1, 1e-9})).
+		When(mat.NewDense(2, 2, []float64{1, 0, 0, 1}), "identity").
+		When(rotation, "rotation").
+		Result()
+
+	assert.Equal(t, "rotation", res)
+}
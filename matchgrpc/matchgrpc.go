@@ -0,0 +1,47 @@
+// Package matchgrpc provides patterns for errors returned by gRPC calls.
+package matchgrpc
+
+import (
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCCode defines the pattern for gRPC errors with the given code. Wrapped
+// status errors are matched too and a nil error has the codes.OK code.
+func GRPCCode(code codes.Code) match.Pattern {
+	return statusPattern{code, match.ANY}
+}
+
+// GRPCStatus defines the pattern for gRPC errors by patterns for the code and
+// the message, e.g. GRPCStatus(match.OneOf(codes.NotFound, codes.Unavailable), regexp.MustCompile("^user")).
+func GRPCStatus(codePattern interface{}, messagePattern interface{}) match.Pattern {
+	return statusPattern{codePattern, messagePattern}
+}
+
+type statusPattern struct {
+	code    interface{}
+	message interface{}
+}
+
+func (sp statusPattern) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	var st *status.Status
+	if value == nil {
+		st = status.New(codes.OK, "")
+	} else {
+		err, ok := value.(error)
+		if !ok {
+			return nil, false
+		}
+
+		if st, ok = status.FromError(err); !ok {
+			return nil, false
+		}
+	}
+
+	if _, matched := matchFunc(sp.code, st.Code()); !matched {
+		return nil, false
+	}
+
+	return matchFunc(sp.message, st.Message())
+}
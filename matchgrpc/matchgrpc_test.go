@@ -0,0 +1,63 @@
+package matchgrpc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	err := status.Error(codes.NotFound, "user 42 not found")
+
+	_, res := match.Match(err).
+		When(GRPCCode(codes.PermissionDenied), "denied").
+		When(GRPCCode(codes.NotFound), "not found").
+		Result()
+
+	assert.Equal(t, "not found", res)
+}
+
+func TestGRPCCodeWrapped(t *testing.T) {
+	err := fmt.Errorf("get user: %w", status.Error(codes.Unavailable, "connection refused"))
+
+	isMatched, _ := match.Match(err).
+		When(GRPCCode(codes.Unavailable), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestGRPCStatus(t *testing.T) {
+	err := status.Error(codes.InvalidArgument, "email: invalid format")
+
+	_, res := match.Match(err).
+		When(GRPCStatus(codes.InvalidArgument, regexp.MustCompile("^name:")), "name").
+		When(GRPCStatus(match.OneOf(codes.InvalidArgument, codes.FailedPrecondition), regexp.MustCompile("^email:")), "email").
+		Result()
+
+	assert.Equal(t, "email", res)
+}
+
+func TestGRPCCodeNotStatusError(t *testing.T) {
+	isMatched, _ := match.Match(errors.New("plain")).
+		When(GRPCCode(codes.Unknown), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestGRPCCodeNil(t *testing.T) {
+	var err error
+
+	isMatched, _ := match.Match(err).
+		When(GRPCCode(codes.OK), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
@@ -0,0 +1,231 @@
+// Package matchlite provides typed pattern matching without reflect, for
+// TinyGo, small WASM targets and other builds where reflect support is
+// limited or binary size matters. Patterns are plain predicates on the type
+// of the matched value, so there are no struct, map or slice patterns
+// resolved at run time like in the match package.
+package matchlite
+
+import "strings"
+
+// Pattern checks whether the value matches.
+type Pattern[T any] func(value T) bool
+
+// Ordered is the constraint of the values compared by Gt, Lt and Between.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+type branch[T any, R any] struct {
+	pattern Pattern[T]
+	action  func(value T) R
+}
+
+// Matcher matches a value of type T against its branches, the action of
+// the first matched branch returns the result of type R.
+type Matcher[T any, R any] struct {
+	value    T
+	branches []branch[T, R]
+}
+
+// Match creates the Matcher for the value, e.g. Match[int, string](code).
+func Match[T any, R any](value T) *Matcher[T, R] {
+	return &Matcher[T, R]{value: value}
+}
+
+// When adds the branch returning the result when the pattern matches.
+func (matcher *Matcher[T, R]) When(pattern Pattern[T], result R) *Matcher[T, R] {
+	return matcher.WhenFunc(pattern, func(T) R { return result })
+}
+
+// WhenFunc adds the branch calling the action with the value when the
+// pattern matches.
+func (matcher *Matcher[T, R]) WhenFunc(pattern Pattern[T], action func(value T) R) *Matcher[T, R] {
+	matcher.branches = append(matcher.branches, branch[T, R]{pattern, action})
+
+	return matcher
+}
+
+// Result returns the result of the first matched branch, or the zero value
+// and false when no branch matches.
+func (matcher *Matcher[T, R]) Result() (bool, R) {
+	return find(matcher.branches, matcher.value)
+}
+
+// RuleSet is a reusable set of branches which can be matched against many
+// values. Branches have to be added before it's used by multiple goroutines.
+type RuleSet[T any, R any] struct {
+	branches []branch[T, R]
+}
+
+// NewRuleSet creates an empty RuleSet.
+func NewRuleSet[T any, R any]() *RuleSet[T, R] {
+	return &RuleSet[T, R]{}
+}
+
+// When adds the branch returning the result when the pattern matches.
+func (rs *RuleSet[T, R]) When(pattern Pattern[T], result R) *RuleSet[T, R] {
+	return rs.WhenFunc(pattern, func(T) R { return result })
+}
+
+// WhenFunc adds the branch calling the action with the value when the
+// pattern matches.
+func (rs *RuleSet[T, R]) WhenFunc(pattern Pattern[T], action func(value T) R) *RuleSet[T, R] {
+	rs.branches = append(rs.branches, branch[T, R]{pattern, action})
+
+	return rs
+}
+
+// Result returns the result of the first branch matching the value.
+func (rs *RuleSet[T, R]) Result(value T) (bool, R) {
+	return find(rs.branches, value)
+}
+
+func find[T any, R any](branches []branch[T, R], value T) (bool, R) {
+	for _, b := range branches {
+		if b.pattern(value) {
+			return true, b.action(value)
+		}
+	}
+
+	var zero R
+	return false, zero
+}
+
+// Any defines the pattern which allows any value.
+func Any[T any]() Pattern[T] {
+	return func(T) bool { return true }
+}
+
+// Eq defines the pattern for values equal to the target.
+func Eq[T comparable](target T) Pattern[T] {
+	return func(value T) bool { return value == target }
+}
+
+// OneOf defines the pattern for values equal to one of the targets.
+func OneOf[T comparable](targets ...T) Pattern[T] {
+	return func(value T) bool {
+		for _, target := range targets {
+			if value == target {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Gt defines the pattern for values greater than the bound.
+func Gt[T Ordered](bound T) Pattern[T] {
+	return func(value T) bool { return value > bound }
+}
+
+// Lt defines the pattern for values less than the bound.
+func Lt[T Ordered](bound T) Pattern[T] {
+	return func(value T) bool { return value < bound }
+}
+
+// Between defines the pattern for values in the range [min, max].
+func Between[T Ordered](min T, max T) Pattern[T] {
+	return func(value T) bool { return value >= min && value <= max }
+}
+
+// HasPrefix defines the pattern for strings starting with the prefix.
+func HasPrefix(prefix string) Pattern[string] {
+	return func(value string) bool { return strings.HasPrefix(value, prefix) }
+}
+
+// HasSuffix defines the pattern for strings ending with the suffix.
+func HasSuffix(suffix string) Pattern[string] {
+	return func(value string) bool { return strings.HasSuffix(value, suffix) }
+}
+
+// Contains defines the pattern for strings containing the substring.
+func Contains(substr string) Pattern[string] {
+	return func(value string) bool { return strings.Contains(value, substr) }
+}
+
+// Not defines the pattern for values which don't match the pattern.
+func Not[T any](pattern Pattern[T]) Pattern[T] {
+	return func(value T) bool { return !pattern(value) }
+}
+
+// And defines the pattern for values matching all the patterns.
+func And[T any](patterns ...Pattern[T]) Pattern[T] {
+	return func(value T) bool {
+		for _, pattern := range patterns {
+			if !pattern(value) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or defines the pattern for values matching at least one of the patterns.
+func Or[T any](patterns ...Pattern[T]) Pattern[T] {
+	return func(value T) bool {
+		for _, pattern := range patterns {
+			if pattern(value) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Len defines the pattern for slices whose length matches the pattern.
+func Len[E any](pattern Pattern[int]) Pattern[[]E] {
+	return func(value []E) bool { return pattern(len(value)) }
+}
+
+// Head defines the pattern for slices whose first elements match the
+// patterns one by one, any elements may follow.
+func Head[E any](patterns ...Pattern[E]) Pattern[[]E] {
+	return func(value []E) bool {
+		return len(value) >= len(patterns) && matchElems(patterns, value)
+	}
+}
+
+// Elems defines the pattern for slices of the same length as the patterns
+// whose elements match the patterns one by one.
+func Elems[E any](patterns ...Pattern[E]) Pattern[[]E] {
+	return func(value []E) bool {
+		return len(value) == len(patterns) && matchElems(patterns, value)
+	}
+}
+
+func matchElems[E any](patterns []Pattern[E], value []E) bool {
+	for i, pattern := range patterns {
+		if !pattern(value[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Every defines the pattern for slices whose every element matches the pattern.
+func Every[E any](pattern Pattern[E]) Pattern[[]E] {
+	return func(value []E) bool {
+		for _, elem := range value {
+			if !pattern(elem) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// HasKey defines the pattern for maps where the value of the key matches
+// the pattern.
+func HasKey[K comparable, V any](key K, pattern Pattern[V]) Pattern[map[K]V] {
+	return func(value map[K]V) bool {
+		elem, ok := value[key]
+		return ok && pattern(elem)
+	}
+}
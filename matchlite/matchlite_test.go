@@ -0,0 +1,78 @@
+package matchlite
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	isMatched, res := Match[int, string](42).
+		When(Eq(1), "one").
+		When(Between(10, 99), "two digits").
+		When(Any[int](), "other").
+		Result()
+
+	assert.True(t, isMatched)
+	assert.Equal(t, "two digits", res)
+
+	isMatched, res = Match[int, string](5).When(Gt(5), "big").Result()
+	assert.False(t, isMatched)
+	assert.Equal(t, "", res)
+}
+
+func TestMatch_WhenFunc(t *testing.T) {
+	_, res := Match[int, string](7).
+		WhenFunc(Lt(0), func(int) string { return "negative" }).
+		WhenFunc(Any[int](), strconv.Itoa).
+		Result()
+
+	assert.Equal(t, "7", res)
+}
+
+func TestRuleSet(t *testing.T) {
+	rs := NewRuleSet[string, int]().
+		When(OneOf("GET", "HEAD"), 1).
+		When(And(HasPrefix("P"), Not(Eq("PATCH"))), 2).
+		When(Or(Contains("ELE"), HasSuffix("CH")), 3)
+
+	for value, expected := range map[string]int{"HEAD": 1, "PUT": 2, "PATCH": 3, "DELETE": 3} {
+		_, res := rs.Result(value)
+		assert.Equal(t, expected, res, value)
+	}
+
+	isMatched, _ := rs.Result("OPTIONS")
+	assert.False(t, isMatched)
+}
+
+func TestSlicePatterns(t *testing.T) {
+	rs := NewRuleSet[[]int, string]().
+		When(Elems[int](), "empty").
+		When(Elems(Eq(1), Any[int]()), "pair starting with 1").
+		When(Head(Eq(1)), "starting with 1").
+		When(Every(Gt(0)), "positive").
+		When(Len[int](Gt(3)), "long")
+
+	for _, c := range []struct {
+		value    []int
+		expected string
+	}{
+		{[]int{}, "empty"},
+		{[]int{1, -5}, "pair starting with 1"},
+		{[]int{1, -5, -6}, "starting with 1"},
+		{[]int{2, 3}, "positive"},
+		{[]int{-1, -2, -3, -4}, "long"},
+	} {
+		_, res := rs.Result(c.value)
+		assert.Equal(t, c.expected, res, c.value)
+	}
+}
+
+func TestHasKey(t *testing.T) {
+	pattern := HasKey("env", OneOf("prod", "staging"))
+
+	assert.True(t, pattern(map[string]string{"env": "prod"}))
+	assert.False(t, pattern(map[string]string{"env": "dev"}))
+	assert.False(t, pattern(map[string]string{}))
+}
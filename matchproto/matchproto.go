@@ -0,0 +1,150 @@
+// Package matchproto provides patterns for proto.Message values which use
+// protoreflect field access, so generated proto types can be matched by
+// some of their fields instead of plain struct equality.
+package matchproto
+
+import (
+	"fmt"
+	"strings"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Fields defines the pattern for proto.Message values by field patterns.
+// Keys are field names as in the .proto file, nested message fields can be
+// used by a dotted path like "user.id". Fields without a pattern match any
+// value, so match.ANY is only needed to require a field of a nested message.
+// Messages without some of the fields don't match.
+//
+// Field values are passed to the patterns as Go values: scalars as is, enums
+// as their generated type, messages as proto.Message (nil when unset),
+// repeated fields as []interface{} and maps as map[interface{}]interface{}.
+func Fields(fields map[string]interface{}) match.Pattern {
+	return fieldsPattern(fields)
+}
+
+// Oneof defines the pattern which matches when the field set in the oneof
+// group is the given field and its value matches the pattern.
+func Oneof(oneof string, field string, pattern interface{}) match.Pattern {
+	return oneofPattern{oneof, field, pattern}
+}
+
+type fieldsPattern map[string]interface{}
+
+func (fp fieldsPattern) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return nil, false
+	}
+
+	for path, pattern := range fp {
+		fieldValue, ok := lookupField(msg.ProtoReflect(), path)
+		if !ok {
+			return nil, false
+		}
+
+		if _, matched := matchFunc(pattern, fieldValue); !matched {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+type oneofPattern struct {
+	oneof   string
+	field   string
+	pattern interface{}
+}
+
+func (op oneofPattern) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return nil, false
+	}
+
+	m := msg.ProtoReflect()
+	oneof := m.Descriptor().Oneofs().ByName(protoreflect.Name(op.oneof))
+	if oneof == nil {
+		panic(&match.PatternError{Pattern: op, Err: fmt.Errorf("%s has no oneof %s", m.Descriptor().FullName(), op.oneof)})
+	}
+
+	fd := m.WhichOneof(oneof)
+	if fd == nil || string(fd.Name()) != op.field {
+		return nil, false
+	}
+
+	return matchFunc(op.pattern, toGo(fd, m.Get(fd)))
+}
+
+// lookupField returns the Go value of the field by the dotted path. It returns
+// false when the message has no such field, a name on the path isn't a message
+// field or a message on the path isn't set.
+func lookupField(m protoreflect.Message, path string) (interface{}, bool) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, false
+		}
+
+		if i == len(names)-1 {
+			return toGo(fd, m.Get(fd)), true
+		}
+
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			return nil, false
+		}
+
+		m = m.Get(fd).Message()
+	}
+
+	return nil, false
+}
+
+func toGo(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		res := make([]interface{}, list.Len())
+		for i := range res {
+			res[i] = singularToGo(fd, list.Get(i))
+		}
+
+		return res
+	case fd.IsMap():
+		m := v.Map()
+		res := make(map[interface{}]interface{}, m.Len())
+		m.Range(func(key protoreflect.MapKey, val protoreflect.Value) bool {
+			res[key.Interface()] = singularToGo(fd.MapValue(), val)
+			return true
+		})
+
+		return res
+	}
+
+	return singularToGo(fd, v)
+}
+
+func singularToGo(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if !v.Message().IsValid() {
+			return nil
+		}
+
+		return v.Message().Interface()
+	case protoreflect.EnumKind:
+		enumType, err := protoregistry.GlobalTypes.FindEnumByName(fd.Enum().FullName())
+		if err != nil {
+			return v.Enum()
+		}
+
+		return enumType.New(v.Enum())
+	}
+
+	return v.Interface()
+}
@@ -0,0 +1,90 @@
+package matchproto
+
+import (
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestFields(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("user.proto"),
+		Package:    proto.String("users"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String("example.com/users")},
+	}
+
+	_, res := match.Match(file).
+		When(Fields(map[string]interface{}{"package": "orders"}), "orders").
+		When(Fields(map[string]interface{}{
+			"package":            "users",
+			"dependency":         []interface{}{match.HEAD, "google/protobuf/timestamp.proto"},
+			"options.go_package": match.HasPrefix("example.com/"),
+		}), "users").
+		Result()
+
+	assert.Equal(t, "users", res)
+}
+
+func TestFieldsUnsetMessage(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{Name: proto.String("a.proto")}
+
+	_, res := match.Match(file).
+		When(Fields(map[string]interface{}{"options.go_package": match.ANY}), "with options").
+		When(Fields(map[string]interface{}{"options": match.IsNull}), "without options").
+		Result()
+
+	assert.Equal(t, "without options", res)
+}
+
+func TestFieldsEnum(t *testing.T) {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:  proto.String("id"),
+		Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+	}
+
+	isMatched, _ := match.Match(field).
+		When(Fields(map[string]interface{}{"label": descriptorpb.FieldDescriptorProto_LABEL_REPEATED}), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestFieldsUnknownField(t *testing.T) {
+	for _, fields := range []map[string]interface{}{
+		{"packages": "users"},
+		{"name.first": "users"},
+		{"name": match.ANY, "packages": "users"},
+	} {
+		isMatched, _ := match.Match(&descriptorpb.FileDescriptorProto{Name: proto.String("users.proto")}).
+			When(Fields(fields), true).
+			Result()
+
+		assert.False(t, isMatched, fields)
+	}
+}
+
+func TestOneof(t *testing.T) {
+	_, res := match.Match(structpb.NewStringValue("gopher")).
+		When(Oneof("kind", "number_value", match.ANY), "number").
+		When(Oneof("kind", "string_value", match.HasPrefix("go")), "string").
+		Result()
+
+	assert.Equal(t, "string", res)
+}
+
+func TestFieldsMap(t *testing.T) {
+	s, _ := structpb.NewStruct(map[string]interface{}{"env": "prod"})
+
+	isMatched, _ := match.Match(s).
+		When(Fields(map[string]interface{}{
+			"fields": map[interface{}]interface{}{"env": Oneof("kind", "string_value", "prod")},
+		}), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
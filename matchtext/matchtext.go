@@ -0,0 +1,124 @@
+// Package matchtext provides Unicode normalization, locale-aware collation
+// and language tag patterns for strings, based on golang.org/x/text.
+package matchtext
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NFC returns the string in the Unicode normalization form C, it's meant for
+// Matcher.WithStringNormalizer.
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// Collate defines the pattern for strings which are equal to the target by
+// the collation rules of the language, e.g. Collate(language.German, "strasse",
+// collate.Loose) matches "Straße". The options are passed to collate.New.
+func Collate(lang language.Tag, target string, options ...collate.Option) match.Pattern {
+	return &collatePattern{
+		lang:     lang,
+		target:   target,
+		collator: collate.New(lang, options...),
+	}
+}
+
+type collatePattern struct {
+	lang   language.Tag
+	target string
+	// collator isn't safe for concurrent use
+	mu       sync.Mutex
+	collator *collate.Collator
+}
+
+func (cp *collatePattern) MatchValue(value interface{}, _ match.MatchFunc) ([]match.MatchItem, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return nil, cp.collator.CompareString(str, cp.target) == 0
+}
+
+func (cp *collatePattern) String() string {
+	return "Collate(" + cp.lang.String() + ", " + strconv.Quote(cp.target) + ")"
+}
+
+// LocaleIn defines the pattern for BCP 47 language tags within any of the
+// ranges, values can be strings or language.Tag. Tags and ranges are
+// canonicalized, so "iw" matches "he" and "en_us" matches "en-US". A range
+// matches the tags equal to it or having more subtags after it, e.g. "de-DE"
+// matches "de-DE-1996". The trailing "*" subtag is allowed: "en-*" is the
+// same as "en", while "*" matches every tag.
+func LocaleIn(ranges ...string) match.Pattern {
+	lp := &localePattern{ranges: ranges}
+	for _, r := range ranges {
+		if r == "*" {
+			lp.prefixes = append(lp.prefixes, "")
+			continue
+		}
+
+		tag, err := language.Parse(strings.TrimSuffix(r, "-*"))
+		if err != nil {
+			lp.err = err
+			break
+		}
+
+		lp.prefixes = append(lp.prefixes, tag.String())
+	}
+
+	return lp
+}
+
+type localePattern struct {
+	ranges   []string
+	prefixes []string
+	err      error
+}
+
+func (lp *localePattern) MatchValue(value interface{}, _ match.MatchFunc) ([]match.MatchItem, bool) {
+	if lp.err != nil {
+		panic(&match.PatternError{Pattern: lp, Err: lp.err})
+	}
+
+	var tag language.Tag
+	switch v := value.(type) {
+	case language.Tag:
+		tag = v
+	case string:
+		var err error
+		if tag, err = language.Parse(v); err != nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	str := tag.String()
+	for _, prefix := range lp.prefixes {
+		if prefix == "" || str == prefix || strings.HasPrefix(str, prefix+"-") {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func (lp *localePattern) String() string {
+	quoted := make([]string, len(lp.ranges))
+	for i, r := range lp.ranges {
+		quoted[i] = strconv.Quote(r)
+	}
+
+	return "LocaleIn(" + strings.Join(quoted, ", ") + ")"
+}
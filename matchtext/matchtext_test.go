@@ -0,0 +1,64 @@
+package matchtext
+
+import (
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestNFC(t *testing.T) {
+	composed, decomposed := "caf\u00e9", "cafe\u0301"
+
+	isMatched, _ := match.Match(decomposed).When(composed, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = match.Match(decomposed).WithStringNormalizer(NFC).When(composed, true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestCollate(t *testing.T) {
+	pattern := Collate(language.French, "cote", collate.IgnoreDiacritics, collate.IgnoreCase)
+
+	isMatched, _ := match.Match("Côté").When(pattern, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = match.Match("coter").When(pattern, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = match.Match(42).When(pattern, true).Result()
+	assert.False(t, isMatched)
+
+	assert.Equal(t, `Collate(fr, "cote")`, match.FormatPattern(pattern))
+}
+
+func TestLocaleIn(t *testing.T) {
+	route := func(value interface{}) interface{} {
+		_, res := match.Match(value).
+			When(LocaleIn("en-*"), "english").
+			When(LocaleIn("de-DE", "de-AT"), "german").
+			When(match.ANY, "default").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "english", route("en"))
+	assert.Equal(t, "english", route("en_GB"))
+	assert.Equal(t, "english", route(language.AmericanEnglish))
+	assert.Equal(t, "german", route("de-de"))
+	assert.Equal(t, "german", route("de-DE-1996"))
+	assert.Equal(t, "default", route("de-CH"))
+	assert.Equal(t, "default", route("not a tag!"))
+
+	isMatched, _ := match.Match("iw").When(LocaleIn("he"), true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestLocaleInInvalid(t *testing.T) {
+	pattern := LocaleIn("en-!!")
+	_, _, err := match.Match("en").When(pattern, true).ResultE()
+	assert.IsType(t, &match.PatternError{}, err)
+	assert.Equal(t, pattern, err.(*match.PatternError).Pattern)
+}
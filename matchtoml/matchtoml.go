@@ -0,0 +1,21 @@
+// Package matchtoml provides matching of TOML documents by the patterns of
+// the match package.
+package matchtoml
+
+import (
+	"github.com/BurntSushi/toml"
+	match "github.com/alexpantyukhin/go-pattern-match"
+)
+
+// MatchTOML function decodes the TOML document and returns the Matcher for it.
+// Tables are map[string]interface{}, arrays []interface{}, integers int64 and
+// datetimes time.Time. Numbers are matched with numeric tolerance, so int
+// patterns match int64 values.
+func MatchTOML(raw []byte) (*match.Matcher, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	return match.Match(doc).WithNumericTolerance(), nil
+}
@@ -0,0 +1,40 @@
+package matchtoml
+
+import (
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+)
+
+const config = `
+title = "app"
+
+[server]
+port = 8080
+hosts = ["a.example.com", "b.example.com"]
+
+[[plugins]]
+name = "auth"
+`
+
+func TestMatchTOML(t *testing.T) {
+	matcher, err := MatchTOML([]byte(config))
+	assert.NoError(t, err)
+
+	_, res := matcher.
+		When(map[string]interface{}{"server": map[string]interface{}{"port": match.Lt(1024)}}, "privileged").
+		When(map[string]interface{}{
+			"title":   "app",
+			"server":  map[string]interface{}{"port": 8080, "hosts": []interface{}{match.Glob("*.example.com")}},
+			"plugins": []interface{}{map[string]interface{}{"name": "auth"}},
+		}, "ok").
+		Result()
+
+	assert.Equal(t, "ok", res)
+}
+
+func TestMatchTOML_Invalid(t *testing.T) {
+	_, err := MatchTOML([]byte("title = "))
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+// Package matchyaml provides matching of YAML documents by the patterns of
+// the match package.
+package matchyaml
+
+import (
+	"fmt"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"gopkg.in/yaml.v3"
+)
+
+// MatchYAML function decodes the YAML document into generic values and
+// returns the Matcher for them. Mappings are decoded to map[string]interface{},
+// keys which aren't strings are formatted, e.g. 1 becomes "1", and sequences
+// to []interface{}. Numbers are matched with numeric tolerance, so an int
+// pattern matches 8080 as well as 8080.0.
+func MatchYAML(raw []byte) (*match.Matcher, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	return match.Match(normalize(doc)).WithNumericTolerance(), nil
+}
+
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+
+		return v
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprint(key)] = normalize(item)
+		}
+
+		return normalized
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+
+		return v
+	}
+
+	return value
+}
@@ -0,0 +1,44 @@
+package matchyaml
+
+import (
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+)
+
+const config = `
+server:
+  port: 8080
+  timeout: 2.5
+  hosts: [a.example.com, b.example.com]
+  1: one
+features:
+  - name: search
+    enabled: true
+`
+
+func TestMatchYAML(t *testing.T) {
+	matcher, err := MatchYAML([]byte(config))
+	assert.NoError(t, err)
+
+	_, res := matcher.
+		When(map[string]interface{}{"server": map[string]interface{}{"port": 80}}, "http").
+		When(map[string]interface{}{
+			"server": map[string]interface{}{
+				"port":    8080.0,
+				"timeout": match.Between(1, 5),
+				"hosts":   []interface{}{match.Glob("*.example.com")},
+				"1":       "one",
+			},
+			"features": []interface{}{map[string]interface{}{"name": "search", "enabled": true}},
+		}, "dev").
+		Result()
+
+	assert.Equal(t, "dev", res)
+}
+
+func TestMatchYAML_Invalid(t *testing.T) {
+	_, err := MatchYAML([]byte("a: [1"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package match
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+func init() {
+	for _, brand := range []string{"isom", "iso2", "iso3", "iso4", "iso5", "iso6", "mp41", "mp42", "avc1", "dash", "M4V ", "M4A ", "f4v "} {
+		RegisterMagic("MP4", MagicSignature{4, []byte("ftyp")}, MagicSignature{8, []byte(brand)})
+	}
+
+	RegisterMagic("MATROSKA", MagicSignature{0, []byte{0x1a, 0x45, 0xdf, 0xa3}})
+	RegisterMagic("WAV", MagicSignature{0, []byte("RIFF")}, MagicSignature{8, []byte("WAVE")})
+}
+
+const (
+	// webmHeadLen is enough for the DocType element of the EBML header.
+	webmHeadLen = 64
+	// wavHeadLen is enough for the fmt chunk following the usual metadata chunks.
+	wavHeadLen = 512
+)
+
+var (
+	// IsMP4 is the pattern for MP4 data, the ftyp box with one of the MP4
+	// brands, so HEIC and AVIF images aren't matched.
+	IsMP4 = Magic("MP4")
+	// IsWebM is the pattern for WebM data, the Matroska EBML header with the
+	// webm DocType.
+	IsWebM interface{} = headFuncPattern{funcPattern{"IsWebM", func(value interface{}) bool {
+		head, ok := peekHead(value, webmHeadLen)
+		return ok && matchValueBool(&matchState{}, Magic("MATROSKA"), head) &&
+			bytes.Contains(head, []byte("\x42\x82\x84webm"))
+	}}, webmHeadLen}
+)
+
+type wavPattern struct {
+	sampleRate interface{}
+}
+
+// IsWAV defines the pattern for WAV data whose sample rate matches the
+// pattern, the sample rate is decoded as uint32 and compared to number
+// patterns by value, e.g. IsWAV(OneOf(44100, 48000)).
+func IsWAV(sampleRatePattern interface{}) interface{} {
+	return wavPattern{sampleRatePattern}
+}
+
+func (wp wavPattern) formatPattern() string {
+	return "IsWAV(" + FormatPattern(wp.sampleRate) + ")"
+}
+
+func (wp wavPattern) headLen() int {
+	return wavHeadLen
+}
+
+func (wp wavPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	head, ok := peekHead(value, wavHeadLen)
+	if !ok || !matchValueBool(ms, Magic("WAV"), head) {
+		return nil, false
+	}
+
+	sampleRate, ok := wavSampleRate(head)
+	if !ok {
+		return nil, false
+	}
+
+	tolerant := *ms
+	tolerant.numericTolerance = true
+
+	return matchValue(&tolerant, wp.sampleRate, sampleRate)
+}
+
+// wavSampleRate finds the fmt chunk among the RIFF chunks of the head.
+func wavSampleRate(head []byte) (uint32, bool) {
+	for offset := 12; offset+8 <= len(head); {
+		id, size := head[offset:offset+4], binary.LittleEndian.Uint32(head[offset+4:])
+		if string(id) == "fmt " {
+			if offset+16 > len(head) {
+				return 0, false
+			}
+
+			return binary.LittleEndian.Uint32(head[offset+12:]), true
+		}
+
+		// chunks are padded to even sizes
+		next := uint64(offset) + 8 + uint64(size) + uint64(size&1)
+		if next > uint64(len(head)) {
+			return 0, false
+		}
+		offset = int(next)
+	}
+
+	return 0, false
+}
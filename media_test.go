@@ -0,0 +1,50 @@
+package match
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func wavHeader(sampleRate uint32) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF\x00\x00\x00\x00WAVE")
+	b.WriteString("LIST\x03\x00\x00\x00abc\x00")
+	b.WriteString("fmt \x10\x00\x00\x00\x01\x00\x02\x00")
+	binary.Write(&b, binary.LittleEndian, sampleRate)
+	b.Write(make([]byte, 8))
+	return b.Bytes()
+}
+
+func TestMedia(t *testing.T) {
+	classify := func(data []byte) interface{} {
+		_, res := Match(data).
+			When(IsMP4, "mp4").
+			When(IsWebM, "webm").
+			When(IsWAV(OneOf(44100, 48000)), "wav").
+			When(Magic("MATROSKA"), "mkv").
+			When(ANY, "unknown").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "mp4", classify([]byte("\x00\x00\x00\x20ftypisom\x00\x00\x02\x00")))
+	assert.Equal(t, "unknown", classify([]byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00")))
+	assert.Equal(t, "webm", classify([]byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\x82\x84webm\x42\x87")))
+	assert.Equal(t, "mkv", classify([]byte("\x1a\x45\xdf\xa3\xa3\x42\x86\x81\x01\x42\x82\x88matroska")))
+	assert.Equal(t, "wav", classify(wavHeader(48000)))
+	assert.Equal(t, "unknown", classify(wavHeader(22050)))
+	assert.Equal(t, "unknown", classify([]byte("RIFF\x00\x00\x00\x00WAVE")))
+}
+
+func TestIsWAV_Reader(t *testing.T) {
+	_, res, err := MatchReader(bufio.NewReader(bytes.NewReader(wavHeader(44100)))).
+		When(IsWAV(Gte(32000)), "hifi").
+		Result()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hifi", res)
+}
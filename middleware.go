@@ -0,0 +1,39 @@
+package match
+
+// MatchFunc checks whether the value matches the pattern of a branch.
+// It returns the matched items which are passed to the branch action.
+type MatchFunc func(pattern interface{}, value interface{}) ([]MatchItem, bool)
+
+// UseMiddleware adds middleware which wraps the evaluation of every branch,
+// e.g. for logging, timing or skipping branches behind a feature flag.
+// Middleware added first is the outermost one.
+func (matcher *Matcher) UseMiddleware(middleware func(next MatchFunc) MatchFunc) *Matcher {
+	matcher.middlewares = append(matcher.middlewares, middleware)
+	matcher.match = matcher.buildMatchFunc()
+
+	return matcher
+}
+
+// matchFunc returns the MatchFunc evaluating the branches, it's built once by
+// Match, so Result doesn't allocate it on every call.
+func (matcher *Matcher) matchFunc() MatchFunc {
+	if matcher.match != nil {
+		return matcher.match
+	}
+
+	return matcher.buildMatchFunc()
+}
+
+func (matcher *Matcher) buildMatchFunc() MatchFunc {
+	return matcher.wrapMatchFunc(func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+		return matchValue(&matcher.state, pattern, value)
+	})
+}
+
+func (matcher *Matcher) wrapMatchFunc(matchFunc MatchFunc) MatchFunc {
+	for i := len(matcher.middlewares) - 1; i >= 0; i-- {
+		matchFunc = matcher.middlewares[i](matchFunc)
+	}
+
+	return matchFunc
+}
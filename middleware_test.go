@@ -0,0 +1,64 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_MiddlewareSeesEveryBranch(t *testing.T) {
+	var patterns []interface{}
+	_, res := Match(3).
+		UseMiddleware(func(next MatchFunc) MatchFunc {
+			return func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+				patterns = append(patterns, pattern)
+				return next(pattern, value)
+			}
+		}).
+		When(1, "one").
+		When(3, "three").
+		When(ANY, "any").
+		Result()
+
+	assert.Equal(t, "three", res)
+	assert.Equal(t, []interface{}{1, 3}, patterns)
+}
+
+func TestMatch_MiddlewareOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) func(next MatchFunc) MatchFunc {
+		return func(next MatchFunc) MatchFunc {
+			return func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+				calls = append(calls, name)
+				return next(pattern, value)
+			}
+		}
+	}
+
+	Match(1).
+		UseMiddleware(trace("outer")).
+		UseMiddleware(trace("inner")).
+		When(1, true).
+		Result()
+
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+}
+
+func TestMatch_MiddlewareSkipsBranch(t *testing.T) {
+	disabled := map[interface{}]bool{1: true}
+	_, res := Match(1).
+		UseMiddleware(func(next MatchFunc) MatchFunc {
+			return func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+				if disabled[pattern] {
+					return nil, false
+				}
+
+				return next(pattern, value)
+			}
+		}).
+		When(1, "flagged").
+		When(ANY, "fallback").
+		Result()
+
+	assert.Equal(t, "fallback", res)
+}
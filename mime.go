@@ -0,0 +1,87 @@
+package match
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MIME defines the pattern for media type strings like the values of the
+// Content-Type header, the parameters are ignored. The media type can be a
+// wildcard like "image/*" or "*/*".
+func MIME(mediaType string) interface{} {
+	return funcPattern{"MIME(" + strconv.Quote(mediaType) + ")", func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+
+		parsed, _, err := mime.ParseMediaType(str)
+		return err == nil && mediaTypeMatches(mediaType, parsed)
+	}}
+}
+
+// SniffedContent defines the pattern for []byte and string payloads whose
+// content type detected by http.DetectContentType matches the media type,
+// which can be a wildcard like "image/*". Payloads detected as text which are
+// valid JSON are detected as "application/json".
+func SniffedContent(mediaType string) interface{} {
+	return headFuncPattern{funcPattern{"SniffedContent(" + strconv.Quote(mediaType) + ")", func(value interface{}) bool {
+		var data []byte
+		switch v := value.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		default:
+			return false
+		}
+
+		return mediaTypeMatches(mediaType, sniffContentType(data))
+	}}, sniffLen}
+}
+
+// sniffLen is the number of bytes used by http.DetectContentType.
+const sniffLen = 512
+
+func sniffContentType(data []byte) string {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if detected == "text/plain" && (json.Valid(data) || len(data) >= sniffLen && isJSONPrefix(data[:sniffLen])) {
+		return "application/json"
+	}
+
+	return detected
+}
+
+// isJSONPrefix reports whether the data is the beginning of a JSON object or
+// array, like the head of a longer document.
+func isJSONPrefix(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' && trimmed[0] != '[' {
+		return false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			return err == io.ErrUnexpectedEOF || err == io.EOF
+		}
+	}
+}
+
+func mediaTypeMatches(pattern string, mediaType string) bool {
+	pattern = strings.ToLower(pattern)
+	if pattern == "*/*" || pattern == mediaType {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMIME(t *testing.T) {
+	isMatched, _ := Match("application/json; charset=utf-8").When(MIME("application/json"), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("IMAGE/PNG").When(MIME("image/*"), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("text/html").When(MIME("image/*"), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match("not a media type;;").When(MIME("*/*"), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestSniffedContent(t *testing.T) {
+	classify := func(payload interface{}) interface{} {
+		_, res := Match(payload).
+			When(SniffedContent("image/*"), "image").
+			When(SniffedContent("application/json"), "json").
+			When(SniffedContent("text/html"), "html").
+			When(SniffedContent("text/*"), "text").
+			When(ANY, "binary").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "image", classify([]byte("\x89PNG\x0D\x0A\x1A\x0A....")))
+	assert.Equal(t, "json", classify(`{"id": 1}`))
+	assert.Equal(t, "html", classify("<!DOCTYPE html><html></html>"))
+	assert.Equal(t, "text", classify("plain words"))
+	assert.Equal(t, "binary", classify([]byte{0, 1, 2, 3}))
+	assert.Equal(t, "binary", classify(42))
+}
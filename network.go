@@ -0,0 +1,190 @@
+package match
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type cidrPattern struct {
+	network string
+	prefix  netip.Prefix
+	err     error
+}
+
+// CIDR defines the pattern for IP addresses within the network, e.g. "10.0.0.0/8".
+// Values can be net.IP, netip.Addr or strings.
+func CIDR(network string) interface{} {
+	prefix, err := netip.ParsePrefix(network)
+	return cidrPattern{network, prefix.Masked(), err}
+}
+
+func (cp cidrPattern) formatPattern() string {
+	return "CIDR(" + strconv.Quote(cp.network) + ")"
+}
+
+func (cp cidrPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if cp.err != nil {
+		panic(&PatternError{Pattern: cp, Err: cp.err})
+	}
+
+	addr, ok := toAddr(value)
+	return nil, ok && cp.prefix.Contains(addr)
+}
+
+type macPrefixPattern struct {
+	prefix string
+	bytes  []byte
+	err    error
+}
+
+// MACPrefix defines the pattern for hardware addresses starting with the
+// prefix, e.g. the OUI "AC:DE:48" of a vendor. The prefix bytes can be
+// separated by colons, hyphens or dots like net.ParseMAC accepts them.
+// Values can be net.HardwareAddr or strings.
+func MACPrefix(prefix string) interface{} {
+	digits := strings.NewReplacer(":", "", "-", "", ".", "").Replace(prefix)
+	b, err := hex.DecodeString(digits)
+	if err == nil && len(b) == 0 {
+		err = errors.New("empty prefix")
+	}
+
+	return macPrefixPattern{prefix, b, err}
+}
+
+func (mp macPrefixPattern) formatPattern() string {
+	return "MACPrefix(" + strconv.Quote(mp.prefix) + ")"
+}
+
+func (mp macPrefixPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if mp.err != nil {
+		panic(&PatternError{Pattern: mp, Err: mp.err})
+	}
+
+	var addr net.HardwareAddr
+	switch v := value.(type) {
+	case net.HardwareAddr:
+		addr = v
+	case string:
+		var err error
+		if addr, err = net.ParseMAC(v); err != nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	return nil, bytes.HasPrefix(addr, mp.bytes)
+}
+
+var (
+	// IPv4 is the pattern for IPv4 addresses (including IPv4-mapped IPv6 ones).
+	IPv4 interface{} = funcPattern{"IPv4", func(value interface{}) bool {
+		addr, ok := toAddr(value)
+		return ok && addr.Is4()
+	}}
+	// IPv6 is the pattern for IPv6 addresses.
+	IPv6 interface{} = funcPattern{"IPv6", func(value interface{}) bool {
+		addr, ok := toAddr(value)
+		return ok && addr.Is6()
+	}}
+)
+
+// Port defines the pattern for the port number, values can be integers,
+// "host:port" strings, netip.AddrPort, *net.TCPAddr or *net.UDPAddr.
+func Port(port int) interface{} {
+	return funcPattern{"Port(" + strconv.Itoa(port) + ")", func(value interface{}) bool {
+		p, ok := toPort(value)
+		return ok && p == port
+	}}
+}
+
+// PortRange defines the pattern for port numbers in the range [min, max],
+// values are the same as for Port.
+func PortRange(min int, max int) interface{} {
+	return funcPattern{"PortRange(" + strconv.Itoa(min) + ", " + strconv.Itoa(max) + ")", func(value interface{}) bool {
+		p, ok := toPort(value)
+		return ok && p >= min && p <= max
+	}}
+}
+
+// WellKnownPort is the pattern for the system ports 0-1023 reserved by IANA.
+var WellKnownPort interface{} = funcPattern{"WellKnownPort", func(value interface{}) bool {
+	p, ok := toPort(value)
+	return ok && p <= 1023
+}}
+
+// toPort returns the port number of the value, it has to be within 0-65535.
+func toPort(value interface{}) (int, bool) {
+	var port int
+	switch v := value.(type) {
+	case netip.AddrPort:
+		return int(v.Port()), v.IsValid()
+	case *net.TCPAddr:
+		if v == nil {
+			return 0, false
+		}
+		port = v.Port
+	case *net.UDPAddr:
+		if v == nil {
+			return 0, false
+		}
+		port = v.Port
+	case string:
+		_, p, err := net.SplitHostPort(v)
+		if err != nil {
+			return 0, false
+		}
+
+		if port, err = strconv.Atoi(p); err != nil {
+			return 0, false
+		}
+	default:
+		val := reflect.ValueOf(value)
+		switch {
+		case isIntKind(val.Kind()):
+			if val.Int() < 0 || val.Int() > 65535 {
+				return 0, false
+			}
+			port = int(val.Int())
+		case isUintKind(val.Kind()):
+			if val.Uint() > 65535 {
+				return 0, false
+			}
+			port = int(val.Uint())
+		default:
+			return 0, false
+		}
+	}
+
+	return port, port >= 0 && port <= 65535
+}
+
+// toAddr converts net.IP, netip.Addr or string value to netip.Addr. IPv4-mapped
+// IPv6 addresses are unmapped, so they match IPv4 networks.
+func toAddr(value interface{}) (netip.Addr, bool) {
+	var addr netip.Addr
+	switch v := value.(type) {
+	case netip.Addr:
+		addr = v
+	case net.IP:
+		var ok bool
+		if addr, ok = netip.AddrFromSlice(v); !ok {
+			return netip.Addr{}, false
+		}
+	case string:
+		var err error
+		if addr, err = netip.ParseAddr(v); err != nil {
+			return netip.Addr{}, false
+		}
+	default:
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), addr.IsValid()
+}
@@ -0,0 +1,109 @@
+package match
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_CIDR(t *testing.T) {
+	_, res := Match("10.1.2.3").
+		When(CIDR("192.168.0.0/16"), "home").
+		When(CIDR("10.0.0.0/8"), "office").
+		Result()
+
+	assert.Equal(t, "office", res)
+}
+
+func TestMatch_CIDRNetIP(t *testing.T) {
+	isMatched, _ := Match(net.ParseIP("192.168.1.1")).
+		When(CIDR("192.168.0.0/16"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_CIDRInvalid(t *testing.T) {
+	pattern := CIDR("10.0.0.0/33")
+	_, _, err := Match("10.1.2.3").
+		When(pattern, true).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
+
+func TestMatch_IPVersion(t *testing.T) {
+	_, v4 := Match(netip.MustParseAddr("127.0.0.1")).
+		When(IPv6, 6).
+		When(IPv4, 4).
+		Result()
+	_, v6 := Match("::1").
+		When(IPv4, 4).
+		When(IPv6, 6).
+		Result()
+	isMatched, _ := Match("not an ip").
+		When(OneOf(IPv4, IPv6), true).
+		Result()
+
+	assert.Equal(t, 4, v4)
+	assert.Equal(t, 6, v6)
+	assert.False(t, isMatched)
+}
+
+func TestMatch_MACPrefix(t *testing.T) {
+	addr, _ := net.ParseMAC("ac:de:48:00:11:22")
+	_, res := Match(addr).
+		When(MACPrefix("00-1B-63"), "apple").
+		When(MACPrefix("AC:DE:48"), "private").
+		Result()
+
+	assert.Equal(t, "private", res)
+
+	isMatched, _ := Match("AC-DE-48-00-11-22").
+		When(MACPrefix("acde.48"), true).
+		Result()
+
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("not a mac").
+		When(MACPrefix("AC:DE:48"), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_MACPrefixInvalid(t *testing.T) {
+	pattern := MACPrefix("AC:DE:4")
+	_, _, err := Match("ac:de:48:00:11:22").
+		When(pattern, true).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
+
+func TestMatch_Port(t *testing.T) {
+	classify := func(value interface{}) interface{} {
+		_, res := Match(value).
+			When(Port(443), "https").
+			When(WellKnownPort, "system").
+			When(PortRange(1024, 49151), "registered").
+			When(ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "https", classify(443))
+	assert.Equal(t, "https", classify("example.com:443"))
+	assert.Equal(t, "https", classify(netip.MustParseAddrPort("[::1]:443")))
+	assert.Equal(t, "system", classify(uint16(22)))
+	assert.Equal(t, "registered", classify(&net.TCPAddr{Port: 8080}))
+	assert.Equal(t, "registered", classify(":5432"))
+	assert.Equal(t, "other", classify(51000))
+	assert.Equal(t, "other", classify(70000))
+	assert.Equal(t, "other", classify("example.com"))
+	assert.Equal(t, "other", classify(-1))
+}
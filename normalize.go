@@ -0,0 +1,23 @@
+package match
+
+// WithStringNormalizer makes literal string patterns match when both sides
+// are equal after the normalization, e.g. Unicode NFC normalization from the
+// matchtext package so "é" typed as one or two code points match. Values
+// are normalized before regexp patterns are checked, the regexps themselves
+// should be written in the normalized form.
+func (matcher *Matcher) WithStringNormalizer(normalize func(string) string) *Matcher {
+	matcher.state.normalizeString = normalize
+
+	return matcher
+}
+
+// WithStringNormalizer makes literal string patterns match when both sides
+// are equal after the normalization, see Matcher.WithStringNormalizer.
+func (rs *RuleSet) WithStringNormalizer(normalize func(string) string) *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.WithStringNormalizer(normalize)
+
+	return rs
+}
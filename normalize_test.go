@@ -0,0 +1,32 @@
+package match
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_WithStringNormalizer(t *testing.T) {
+	isMatched, _ := Match("HELLO").When("hello", true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match("HELLO").WithStringNormalizer(strings.ToLower).When("hello", true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]string{"A", "B"}).
+		WithStringNormalizer(strings.ToLower).
+		When([]interface{}{"a", regexp.MustCompile("^b$")}, true).
+		Result()
+	assert.True(t, isMatched)
+}
+
+func TestRuleSet_WithStringNormalizer(t *testing.T) {
+	rs := NewRuleSet().
+		WithStringNormalizer(strings.TrimSpace).
+		When("ok", true)
+
+	isMatched, _ := rs.Result(" ok\n")
+	assert.True(t, isMatched)
+}
@@ -0,0 +1,73 @@
+package match
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type pathPattern struct {
+	pattern  string
+	segments []string
+	err      error
+}
+
+// PathPattern defines the pattern for slash-delimited paths, e.g.
+// PathPattern("/users/:id/orders/*rest") matches "/users/42/orders/7/items".
+// A :name segment matches one non-empty segment, a *name segment must be the
+// last one and matches the rest of the path, possibly empty. The action gets
+// the captures as map[string]string.
+func PathPattern(pattern string) interface{} {
+	pp := pathPattern{pattern: pattern, segments: strings.Split(pattern, "/")}
+	for i, segment := range pp.segments {
+		if strings.HasPrefix(segment, "*") && i != len(pp.segments)-1 {
+			pp.err = errors.New("*" + segment[1:] + " must be the last segment of " + strconv.Quote(pattern))
+		}
+	}
+
+	return pp
+}
+
+func (pp pathPattern) formatPattern() string {
+	return "PathPattern(" + strconv.Quote(pp.pattern) + ")"
+}
+
+func (pp pathPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if pp.err != nil {
+		panic(&PatternError{Pattern: pp, Err: pp.err})
+	}
+
+	path, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	segments := strings.Split(path, "/")
+	captures := map[string]string{}
+	for i, segment := range pp.segments {
+		if strings.HasPrefix(segment, "*") {
+			captures[segment[1:]] = strings.Join(segments[min(i, len(segments)):], "/")
+			return []MatchItem{{value: captures}}, true
+		}
+
+		if i >= len(segments) {
+			return nil, false
+		}
+
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			if segments[i] == "" {
+				return nil, false
+			}
+			captures[segment[1:]] = segments[i]
+		case segment != segments[i]:
+			return nil, false
+		}
+	}
+
+	if len(segments) != len(pp.segments) {
+		return nil, false
+	}
+
+	return []MatchItem{{value: captures}}, true
+}
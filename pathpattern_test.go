@@ -0,0 +1,35 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathPattern(t *testing.T) {
+	route := func(path string) interface{} {
+		_, res := Match(path).
+			When(PathPattern("/users/:id"), func(params MatchItem) interface{} {
+				return "user " + params.Value().(map[string]string)["id"]
+			}).
+			When(PathPattern("/users/:id/orders/*rest"), func(params MatchItem) interface{} {
+				return params.Value()
+			}).
+			When(ANY, "not found").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "user 42", route("/users/42"))
+	assert.Equal(t, map[string]string{"id": "42", "rest": "7/items"}, route("/users/42/orders/7/items"))
+	assert.Equal(t, map[string]string{"id": "42", "rest": ""}, route("/users/42/orders"))
+	assert.Equal(t, "not found", route("/users/"))
+	assert.Equal(t, "not found", route("/users/42/friends"))
+}
+
+func TestPathPattern_Invalid(t *testing.T) {
+	pattern := PathPattern("/*rest/b")
+	_, _, err := Match("/a/b").When(pattern, true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
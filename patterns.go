@@ -0,0 +1,198 @@
+package match
+
+import (
+	"bytes"
+	"math"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// funcPattern is the pattern checked by the func, name is used by FormatPattern.
+type funcPattern struct {
+	name  string
+	check func(value interface{}) bool
+}
+
+func (p funcPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	return nil, p.check(value)
+}
+
+func (p funcPattern) formatPattern() string {
+	return p.name
+}
+
+// headPattern is implemented by the patterns which check only the first
+// bytes of the data, MatchReader reads as much as they need.
+type headPattern interface {
+	headLen() int
+}
+
+// headFuncPattern is funcPattern which checks at most n first bytes.
+type headFuncPattern struct {
+	funcPattern
+	n int
+}
+
+func (p headFuncPattern) headLen() int {
+	return p.n
+}
+
+// HasPrefix defines the pattern for strings and []byte which start with the prefix.
+func HasPrefix(prefix string) interface{} {
+	return headFuncPattern{funcPattern{"HasPrefix(" + strconv.Quote(prefix) + ")", func(value interface{}) bool {
+		switch v := value.(type) {
+		case string:
+			return strings.HasPrefix(v, prefix)
+		case []byte:
+			return bytes.HasPrefix(v, []byte(prefix))
+		}
+
+		return false
+	}}, len(prefix)}
+}
+
+// Between defines the pattern for numbers in the range [min, max].
+// Numbers of different types are compared by value.
+func Between(min interface{}, max interface{}) interface{} {
+	return funcPattern{"Between(" + FormatPattern(min) + ", " + FormatPattern(max) + ")", func(value interface{}) bool {
+		lower, ok := compareNumbers(value, min)
+		if !ok || lower < 0 {
+			return false
+		}
+
+		upper, ok := compareNumbers(value, max)
+		return ok && upper <= 0
+	}}
+}
+
+// Gt defines the pattern for numbers greater than the bound.
+func Gt(bound interface{}) interface{} {
+	return comparePattern("Gt", bound, func(cmp int) bool { return cmp > 0 })
+}
+
+// Gte defines the pattern for numbers greater than or equal to the bound.
+func Gte(bound interface{}) interface{} {
+	return comparePattern("Gte", bound, func(cmp int) bool { return cmp >= 0 })
+}
+
+// Lt defines the pattern for numbers less than the bound.
+func Lt(bound interface{}) interface{} {
+	return comparePattern("Lt", bound, func(cmp int) bool { return cmp < 0 })
+}
+
+// Lte defines the pattern for numbers less than or equal to the bound.
+func Lte(bound interface{}) interface{} {
+	return comparePattern("Lte", bound, func(cmp int) bool { return cmp <= 0 })
+}
+
+func comparePattern(name string, bound interface{}, accept func(cmp int) bool) interface{} {
+	return funcPattern{name + "(" + FormatPattern(bound) + ")", func(value interface{}) bool {
+		cmp, ok := compareNumbers(value, bound)
+		return ok && accept(cmp)
+	}}
+}
+
+// compareNumbers compares numbers of any numeric kinds, including the
+// arbitrary-precision ones, see compareBig. The second result is false
+// when one of the values isn't a number or is NaN.
+func compareNumbers(a interface{}, b interface{}) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	if isBigNumber(a) || isBigNumber(b) {
+		return compareBig(a, b)
+	}
+
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case isIntKind(av.Kind()) && isIntKind(bv.Kind()):
+		return compareOrdered(av.Int(), bv.Int()), true
+	case isUintKind(av.Kind()) && isUintKind(bv.Kind()):
+		return compareOrdered(av.Uint(), bv.Uint()), true
+	case isIntKind(av.Kind()) && isUintKind(bv.Kind()):
+		if av.Int() < 0 {
+			return -1, true
+		}
+		return compareOrdered(uint64(av.Int()), bv.Uint()), true
+	case isUintKind(av.Kind()) && isIntKind(bv.Kind()):
+		if bv.Int() < 0 {
+			return 1, true
+		}
+		return compareOrdered(av.Uint(), uint64(bv.Int())), true
+	}
+
+	af, ok := toFloat(av)
+	if !ok {
+		return 0, false
+	}
+
+	bf, ok := toFloat(bv)
+	if !ok || math.IsNaN(af) || math.IsNaN(bf) {
+		return 0, false
+	}
+
+	return compareOrdered(af, bf), true
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func toFloat(val reflect.Value) (float64, bool) {
+	switch {
+	case isIntKind(val.Kind()):
+		return float64(val.Int()), true
+	case isUintKind(val.Kind()):
+		return float64(val.Uint()), true
+	case val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64:
+		return val.Float(), true
+	}
+
+	return 0, false
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+type globPattern struct {
+	pattern string
+}
+
+// Glob defines the pattern for strings matching the shell pattern, see path.Match
+// for the syntax. Note that '*' doesn't match '/'.
+func Glob(pattern string) interface{} {
+	return globPattern{pattern}
+}
+
+func (gp globPattern) formatPattern() string {
+	return "Glob(" + strconv.Quote(gp.pattern) + ")"
+}
+
+func (gp globPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	matched, err := path.Match(gp.pattern, str)
+	if err != nil {
+		panic(&PatternError{Pattern: gp, Err: err})
+	}
+
+	return nil, matched
+}
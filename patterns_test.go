@@ -0,0 +1,93 @@
+package match
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_HasPrefix(t *testing.T) {
+	isMatched, _ := Match("gopher").
+		When(HasPrefix("go"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_Between(t *testing.T) {
+	_, res := Match(uint8(200)).
+		When(Between(0, 100), "low").
+		When(Between(100.5, 255), "high").
+		Result()
+
+	assert.Equal(t, "high", res)
+}
+
+func TestMatch_BetweenNotNumber(t *testing.T) {
+	isMatched, _ := Match("10").
+		When(Between(0, 100), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_CompareNaN(t *testing.T) {
+	nan := math.NaN()
+	for _, pattern := range []interface{}{Between(0, 10), Gte(0), Lte(10), Gt(-1), Lt(11)} {
+		isMatched, _ := Match(nan).When(pattern, true).Result()
+		assert.False(t, isMatched, FormatPattern(pattern))
+	}
+
+	isMatched, _ := Match(5).When(Between(0, nan), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(nan).WithNumericTolerance().When(1, true).When(nan, true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestMatch_OneOfTopLevel(t *testing.T) {
+	isMatched, _ := Match(3).
+		When(OneOf(1, 2, 3), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_Glob(t *testing.T) {
+	isMatched, _ := Match("report-2024.csv").
+		When(Glob("report-*.csv"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_GlobInvalid(t *testing.T) {
+	pattern := Glob("[")
+	_, _, err := Match("report").
+		When(pattern, true).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
+
+type evenPattern struct{}
+
+func (evenPattern) MatchValue(value interface{}, match MatchFunc) ([]MatchItem, bool) {
+	n, ok := value.(int)
+	if !ok {
+		return nil, false
+	}
+
+	return match(true, n%2 == 0)
+}
+
+func TestMatch_ExternalPattern(t *testing.T) {
+	_, res := Match([]int{1, 4}).
+		When([]interface{}{evenPattern{}, ANY}, "even first").
+		When([]interface{}{ANY, evenPattern{}}, "even second").
+		Result()
+
+	assert.Equal(t, "even second", res)
+}
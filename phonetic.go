@@ -0,0 +1,111 @@
+package match
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Soundex defines the pattern for strings with the same American Soundex
+// code as the word, e.g. Soundex("smith") matches "Smyth".
+func Soundex(word string) interface{} {
+	code := soundex(word)
+	return funcPattern{"Soundex(" + strconv.Quote(word) + ")", func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && code != "" && soundex(str) == code
+	}}
+}
+
+// TokenSet defines the pattern for strings containing all the words, in any
+// order and case, e.g. TokenSet("error timeout") matches "Timeout error on read".
+func TokenSet(words string) interface{} {
+	return tokenSetPattern("TokenSet("+strconv.Quote(words)+")", words, 1)
+}
+
+// TokenOverlap defines the pattern for strings containing at least the
+// threshold share of the words, e.g. 0.5 for half of them.
+func TokenOverlap(words string, threshold float64) interface{} {
+	return tokenSetPattern(fmt.Sprintf("TokenOverlap(%q, %v)", words, threshold), words, threshold)
+}
+
+func tokenSetPattern(name string, words string, threshold float64) interface{} {
+	expected := tokenize(words)
+	return funcPattern{name, func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok || len(expected) == 0 {
+			return false
+		}
+
+		tokens := tokenize(str)
+		found := 0
+		for token := range expected {
+			if _, ok := tokens[token]; ok {
+				found++
+			}
+		}
+
+		return float64(found)/float64(len(expected)) >= threshold
+	}}
+}
+
+func tokenize(str string) map[string]struct{} {
+	tokens := map[string]struct{}{}
+	for _, token := range strings.FieldsFunc(strings.ToLower(str), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		tokens[token] = struct{}{}
+	}
+
+	return tokens
+}
+
+var soundexCodes = map[rune]byte{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// soundex returns the code of the word, letters other than a-z are ignored.
+func soundex(word string) string {
+	code := make([]byte, 0, 4)
+	var last byte
+	for _, r := range strings.ToLower(word) {
+		if r < 'a' || r > 'z' {
+			continue
+		}
+
+		digit := soundexCodes[r]
+		if len(code) == 0 {
+			code = append(code, byte(unicode.ToUpper(r)))
+			last = digit
+			continue
+		}
+
+		// h and w don't separate letters with the same code, vowels do
+		if r == 'h' || r == 'w' {
+			continue
+		}
+
+		if digit != 0 && digit != last {
+			code = append(code, digit)
+			if len(code) == 4 {
+				break
+			}
+		}
+		last = digit
+	}
+
+	if len(code) == 0 {
+		return ""
+	}
+
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+
+	return string(code)
+}
@@ -0,0 +1,38 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoundex(t *testing.T) {
+	assert.Equal(t, "R163", soundex("Robert"))
+	assert.Equal(t, "R163", soundex("Rupert"))
+	assert.Equal(t, "A261", soundex("Ashcraft"))
+	assert.Equal(t, "T522", soundex("Tymczak"))
+	assert.Equal(t, "P236", soundex("Pfister"))
+	assert.Equal(t, "", soundex("42"))
+
+	isMatched, _ := Match("Smyth").When(Soundex("smith"), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("Jones").When(Soundex("smith"), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestTokenSet(t *testing.T) {
+	triage := func(line string) interface{} {
+		_, res := Match(line).
+			When(TokenSet("connection timeout"), "network").
+			When(TokenOverlap("disk full quota", 0.6), "storage").
+			When(ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "network", triage("Timeout while opening connection"))
+	assert.Equal(t, "storage", triage("write failed: disk full"))
+	assert.Equal(t, "other", triage("disk error"))
+	assert.Equal(t, `TokenOverlap("a b", 0.5)`, FormatPattern(TokenOverlap("a b", 0.5)))
+}
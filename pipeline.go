@@ -0,0 +1,53 @@
+package match
+
+// Pipeline is the result of the matching process which can be transformed
+// and matched again, for multi-stage classification:
+//
+//	Match(v).When(...).Pipe().
+//		Then(normalize).
+//		ThenMatch(func(m *Matcher) *Matcher { return m.When(...) }).
+//		Result()
+//
+// Once a stage doesn't match or fails, the following stages are skipped.
+type Pipeline struct {
+	matched bool
+	value   interface{}
+	err     error
+}
+
+// Pipe runs the matching process and returns its result as Pipeline.
+func (matcher *Matcher) Pipe() *Pipeline {
+	matched, value, err := matcher.ResultE()
+	return &Pipeline{matched, value, err}
+}
+
+// Then transforms the result value with the func.
+func (p *Pipeline) Then(fun func(value interface{}) interface{}) *Pipeline {
+	if !p.matched || p.err != nil {
+		return p
+	}
+
+	return &Pipeline{true, fun(p.value), nil}
+}
+
+// ThenMatch matches the result value again. The build func gets the Matcher
+// for the value and adds the branches to it.
+func (p *Pipeline) ThenMatch(build func(matcher *Matcher) *Matcher) *Pipeline {
+	if !p.matched || p.err != nil {
+		return p
+	}
+
+	return build(Match(p.value)).Pipe()
+}
+
+// Result returns the result of the last stage.
+func (p *Pipeline) Result() (bool, interface{}) {
+	return p.matched, p.value
+}
+
+// ResultE returns the result of the last stage and the error of the stage
+// which failed: *PatternError for invalid pattern usage or *NoMatchError
+// when no pattern of the stage matched.
+func (p *Pipeline) ResultE() (bool, interface{}, error) {
+	return p.matched, p.value, p.err
+}
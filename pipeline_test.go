@@ -0,0 +1,45 @@
+package match
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_ThenMatch(t *testing.T) {
+	_, res := Match("  ERROR: disk full ").
+		When(HasPrefix("  "), func() interface{} { return "trimmed" }).
+		When(ANY, "raw").
+		Pipe().
+		Then(func(value interface{}) interface{} { return strings.ToLower(value.(string)) }).
+		ThenMatch(func(m *Matcher) *Matcher {
+			return m.When("trimmed", 1).When(ANY, 2)
+		}).
+		Result()
+
+	assert.Equal(t, 1, res)
+}
+
+func TestPipeline_StopsWhenNotMatched(t *testing.T) {
+	called := false
+	isMatched, res := Match(1).
+		When(2, "two").
+		Pipe().
+		Then(func(value interface{}) interface{} { called = true; return value }).
+		Result()
+
+	assert.False(t, isMatched)
+	assert.Nil(t, res)
+	assert.False(t, called)
+}
+
+func TestPipeline_ResultE(t *testing.T) {
+	_, _, err := Match(1).
+		When(ANY, []int{1, 2}).
+		Pipe().
+		ThenMatch(func(m *Matcher) *Matcher { return m.When([]interface{}{1, HEAD}, true) }).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+}
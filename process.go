@@ -0,0 +1,89 @@
+//go:build !plan9
+
+package match
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// waitStatus is implemented by syscall.WaitStatus of the platforms
+// supporting signals.
+type waitStatus interface {
+	Signaled() bool
+	Signal() syscall.Signal
+	CoreDump() bool
+}
+
+// Exited defines the pattern for processes which exited normally, not by a
+// signal, with the exit code matching the pattern. Values can be
+// *os.ProcessState, *exec.ExitError or CommandResult.
+func Exited(codePattern interface{}) interface{} {
+	return processPattern{"Exited", func(ms *matchState, state *os.ProcessState) ([]MatchItem, bool) {
+		if !state.Exited() {
+			return nil, false
+		}
+
+		return matchValue(ms, codePattern, state.ExitCode())
+	}, codePattern}
+}
+
+// Signaled defines the pattern for processes terminated by a signal matching
+// the pattern, e.g. Signaled(OneOf(syscall.SIGKILL, syscall.SIGSEGV)). The
+// signal is syscall.Signal, so os.Signal values received by signal.Notify
+// are matched by the same literals.
+func Signaled(signalPattern interface{}) interface{} {
+	return processPattern{"Signaled", func(ms *matchState, state *os.ProcessState) ([]MatchItem, bool) {
+		status, ok := state.Sys().(waitStatus)
+		if !ok || !status.Signaled() {
+			return nil, false
+		}
+
+		return matchValue(ms, signalPattern, status.Signal())
+	}, signalPattern}
+}
+
+// CoreDumped is the pattern for processes terminated by a signal with a core dump.
+var CoreDumped interface{} = processPattern{"CoreDumped", func(_ *matchState, state *os.ProcessState) ([]MatchItem, bool) {
+	status, ok := state.Sys().(waitStatus)
+	return nil, ok && status.Signaled() && status.CoreDump()
+}, nil}
+
+type processPattern struct {
+	name    string
+	match   func(ms *matchState, state *os.ProcessState) ([]MatchItem, bool)
+	pattern interface{}
+}
+
+func (pp processPattern) formatPattern() string {
+	if pp.pattern == nil {
+		return pp.name
+	}
+
+	return pp.name + "(" + FormatPattern(pp.pattern) + ")"
+}
+
+func (pp processPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	var state *os.ProcessState
+	switch v := value.(type) {
+	case *os.ProcessState:
+		state = v
+	case *exec.ExitError:
+		if v != nil {
+			state = v.ProcessState
+		}
+	case CommandResult:
+		state = v.State
+	case *CommandResult:
+		if v != nil {
+			state = v.State
+		}
+	}
+
+	if state == nil {
+		return nil, false
+	}
+
+	return pp.match(ms, state)
+}
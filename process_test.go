@@ -0,0 +1,51 @@
+//go:build !plan9 && !windows
+
+package match
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func restartPolicy(t *testing.T, script string) interface{} {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	matcher, err := RunAndMatch(exec.Command("sh", "-c", script))
+	assert.NoError(t, err)
+
+	_, res := matcher.
+		When(Exited(0), "done").
+		When(CoreDumped, "alert").
+		When(Signaled(OneOf(syscall.SIGTERM, syscall.SIGINT)), "stopped").
+		When(Signaled(ANY), "restart").
+		When(Exited(Between(1, 125)), "backoff").
+		Result()
+	return res
+}
+
+func TestProcessPatterns(t *testing.T) {
+	assert.Equal(t, "done", restartPolicy(t, "exit 0"))
+	assert.Equal(t, "backoff", restartPolicy(t, "exit 2"))
+	assert.Equal(t, "stopped", restartPolicy(t, "kill -TERM $$"))
+	assert.Equal(t, "restart", restartPolicy(t, "kill -KILL $$"))
+}
+
+func TestProcessPatterns_ExitError(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	err := exec.Command("sh", "-c", "exit 4").Run()
+
+	isMatched, _ := Match(err).When(Exited(4), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match("exit 4").When(Exited(ANY), true).Result()
+	assert.False(t, isMatched)
+	assert.Equal(t, "Signaled(OneOf(killed))", FormatPattern(Signaled(OneOf(syscall.SIGKILL))))
+}
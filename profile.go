@@ -0,0 +1,196 @@
+package match
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MatcherStats records the cost of every branch evaluation of the matchers
+// it's attached to by Matcher.WithStats or RuleSet.WithStats, so expensive
+// branches of production rule sets can be found. Matchers built by the same
+// code, e.g. per request, can share a MatcherStats, the branches are
+// aggregated by their index. It is safe for concurrent use.
+type MatcherStats struct {
+	mu       sync.Mutex
+	branches []BranchStats
+	compiles uint64
+	compile  time.Duration
+	// allocSampling is the period of the evaluations whose allocations are counted
+	allocSampling uint64
+	evaluations   atomic.Uint64
+}
+
+// BranchStats is the cost of a branch recorded by MatcherStats.
+type BranchStats struct {
+	Pattern     string
+	Evaluations uint64
+	Matches     uint64
+	// Time is the total time spent evaluating the branch
+	Time time.Duration
+	// AllocSamples are the evaluations whose allocations were counted in Allocs
+	AllocSamples uint64
+	Allocs       uint64
+}
+
+// AllocsPerEvaluation returns the average number of heap allocations of the
+// sampled evaluations, or 0 when none were sampled.
+func (bs BranchStats) AllocsPerEvaluation() float64 {
+	if bs.AllocSamples == 0 {
+		return 0
+	}
+
+	return float64(bs.Allocs) / float64(bs.AllocSamples)
+}
+
+// NewMatcherStats creates MatcherStats which count the allocations of every
+// allocSampling-th evaluation, 0 disables the counting. The allocations are
+// read by runtime.ReadMemStats, which stops the world and counts allocations
+// of other goroutines too, so keep the sampling sparse in production and use
+// the benchmarks package for exact numbers.
+func NewMatcherStats(allocSampling int) *MatcherStats {
+	if allocSampling < 0 {
+		allocSampling = 0
+	}
+
+	return &MatcherStats{allocSampling: uint64(allocSampling)}
+}
+
+// WithStats records the cost of the branch evaluations to the stats.
+func (matcher *Matcher) WithStats(stats *MatcherStats) *Matcher {
+	matcher.stats = stats
+
+	return matcher
+}
+
+// WithStats records the cost of the branch evaluations to the stats, see
+// Matcher.WithStats. Building the string literal index of the branches is
+// recorded as the compile cost. Consecutive regexp branches are matched by
+// one combined regexp, its cost is recorded for the first of them unless
+// another one matched.
+func (rs *RuleSet) WithStats(stats *MatcherStats) *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.WithStats(stats)
+
+	return rs
+}
+
+// Branches returns the stats of the branches in the order they were added.
+func (stats *MatcherStats) Branches() []BranchStats {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	return append([]BranchStats(nil), stats.branches...)
+}
+
+// CompileCost returns how many times the RuleSet index of the branches was
+// built and the total time it took. It's rebuilt after branches are added
+// and when an Adaptive RuleSet reorders its branches.
+func (stats *MatcherStats) CompileCost() (uint64, time.Duration) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	return stats.compiles, stats.compile
+}
+
+// Report writes the branches which were evaluated, the most expensive first.
+func (stats *MatcherStats) Report(w io.Writer) error {
+	branches := stats.Branches()
+	indices := make([]int, 0, len(branches))
+	for i, branch := range branches {
+		if branch.Evaluations > 0 {
+			indices = append(indices, i)
+		}
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return branches[indices[i]].Time > branches[indices[j]].Time
+	})
+
+	compiles, compile := stats.CompileCost()
+	if _, err := fmt.Fprintf(w, "match stats: %d branches evaluated, compiled %d times in %s\n", len(indices), compiles, compile); err != nil {
+		return err
+	}
+
+	for _, i := range indices {
+		branch := branches[i]
+		_, err := fmt.Fprintf(w, "branch %d %s: %d evaluations, %d matches, %s total, %s per evaluation, %.1f allocs per evaluation\n",
+			i, truncate(branch.Pattern), branch.Evaluations, branch.Matches, branch.Time,
+			branch.Time/time.Duration(branch.Evaluations), branch.AllocsPerEvaluation())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evaluate calls the evaluation and records its cost for the branch it
+// returns the index of.
+func (stats *MatcherStats) evaluate(matchItems []matchItem, evaluation func() (int, bool)) (int, bool) {
+	sampled := stats.allocSampling > 0 && stats.evaluations.Add(1)%stats.allocSampling == 0
+	var before, after runtime.MemStats
+	if sampled {
+		runtime.ReadMemStats(&before)
+	}
+
+	start := time.Now()
+	index, matched := evaluation()
+	elapsed := time.Since(start)
+
+	if sampled {
+		runtime.ReadMemStats(&after)
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	for len(stats.branches) <= index {
+		stats.branches = append(stats.branches, BranchStats{})
+	}
+	branch := &stats.branches[index]
+	if branch.Evaluations == 0 {
+		branch.Pattern = FormatPattern(matchItems[index].pattern)
+	}
+	branch.Evaluations++
+	branch.Time += elapsed
+	if matched {
+		branch.Matches++
+	}
+	if sampled {
+		branch.AllocSamples++
+		branch.Allocs += after.Mallocs - before.Mallocs
+	}
+
+	return index, matched
+}
+
+// matchBranch matches the value against the branch at the index, recording
+// the cost when stats are attached.
+func (stats *MatcherStats) matchBranch(matchItems []matchItem, index int, matchFunc MatchFunc, value interface{}) ([]MatchItem, bool) {
+	if stats == nil {
+		return matchFunc(matchItems[index].pattern, value)
+	}
+
+	var matchedItems []MatchItem
+	_, matched := stats.evaluate(matchItems, func() (int, bool) {
+		var matched bool
+		matchedItems, matched = matchFunc(matchItems[index].pattern, value)
+		return index, matched
+	})
+
+	return matchedItems, matched
+}
+
+func (stats *MatcherStats) compiled(elapsed time.Duration) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.compiles++
+	stats.compile += elapsed
+}
@@ -0,0 +1,61 @@
+package match
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcherStats(t *testing.T) {
+	stats := NewMatcherStats(1)
+	for _, val := range []interface{}{1, 2, 3} {
+		Match(val).WithStats(stats).
+			When(1, "one").
+			When(Gt(1), "many").
+			Result()
+	}
+
+	branches := stats.Branches()
+	assert.Len(t, branches, 2)
+	assert.Equal(t, "1", branches[0].Pattern)
+	assert.Equal(t, uint64(3), branches[0].Evaluations)
+	assert.Equal(t, uint64(1), branches[0].Matches)
+	assert.Equal(t, uint64(2), branches[1].Evaluations)
+	assert.Equal(t, uint64(2), branches[1].Matches)
+	assert.Equal(t, uint64(2), branches[1].AllocSamples)
+	assert.True(t, branches[1].Time > 0)
+
+	var buf bytes.Buffer
+	assert.NoError(t, stats.Report(&buf))
+	assert.Contains(t, buf.String(), "match stats: 2 branches evaluated, compiled 0 times")
+	assert.Contains(t, buf.String(), "branch 0 1: 3 evaluations, 1 matches")
+}
+
+func TestMatcherStats_RuleSet(t *testing.T) {
+	// registered matchers of other tests disable the literal index
+	isolateRegisteredMatchers(t)
+
+	stats := NewMatcherStats(0)
+	rs := NewRuleSet().WithStats(stats).
+		When(regexp.MustCompile("^a"), "a").
+		When(regexp.MustCompile("^b"), "b").
+		When("c", "c").
+		When(ANY, "other")
+
+	for _, val := range []interface{}{"b", "c", "d", 1} {
+		rs.Result(val)
+	}
+
+	branches := stats.Branches()
+	assert.Equal(t, uint64(3), branches[0].Evaluations)
+	assert.Equal(t, uint64(0), branches[0].Matches)
+	assert.Equal(t, uint64(1), branches[1].Matches)
+	assert.Equal(t, uint64(1), branches[2].Matches)
+	assert.Equal(t, uint64(2), branches[3].Matches)
+	assert.Equal(t, uint64(0), branches[3].AllocSamples)
+
+	compiles, _ := stats.CompileCost()
+	assert.Equal(t, uint64(1), compiles)
+}
@@ -0,0 +1,85 @@
+package match
+
+import (
+	"bytes"
+	"io"
+)
+
+// ReaderMatcher matches the head of a stream, see MatchReader.
+type ReaderMatcher struct {
+	r       io.Reader
+	matcher *Matcher
+	head    []byte
+	read    bool
+}
+
+// MatchReader takes a stream for matching by patterns checking the first
+// bytes of it, like Magic, HasPrefix, SniffedContent or IsGzip. Only as many
+// bytes as the longest of the patterns needs are read, 512 bytes for other
+// patterns, which get the head as []byte. Reader returns the stream with
+// the head put back, so it can be processed as if nothing was read.
+func MatchReader(r io.Reader) *ReaderMatcher {
+	return &ReaderMatcher{r: r, matcher: Match(nil)}
+}
+
+// When function adds new pattern for checking matching, see Matcher.When.
+func (rm *ReaderMatcher) When(pattern interface{}, action interface{}) *ReaderMatcher {
+	rm.matcher.When(pattern, action)
+
+	return rm
+}
+
+// Result reads the head of the stream and returns the result value of
+// matching it. The error is returned when the stream can't be read, the
+// bytes read before the error are still returned by Reader.
+func (rm *ReaderMatcher) Result() (bool, interface{}, error) {
+	if !rm.read {
+		head := make([]byte, rm.headLen())
+		n, err := io.ReadFull(rm.r, head)
+		rm.head, rm.read = head[:n], true
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return false, nil, err
+		}
+	}
+
+	rm.matcher.value = rm.head
+	matched, res := rm.matcher.Result()
+
+	return matched, res, nil
+}
+
+// Reader returns the stream including the bytes read by Result.
+func (rm *ReaderMatcher) Reader() io.Reader {
+	if !rm.read {
+		return rm.r
+	}
+
+	return io.MultiReader(bytes.NewReader(rm.head), rm.r)
+}
+
+func (rm *ReaderMatcher) headLen() int {
+	n := 0
+	for _, mi := range rm.matcher.matchItems {
+		n = max(n, patternHeadLen(mi.pattern))
+	}
+
+	return n
+}
+
+func patternHeadLen(pattern interface{}) int {
+	switch p := pattern.(type) {
+	case matchKey:
+		return 0
+	case headPattern:
+		return p.headLen()
+	case oneOfContainer:
+		n := 0
+		for _, item := range p.items {
+			n = max(n, patternHeadLen(item))
+		}
+
+		return n
+	}
+
+	return sniffLen
+}
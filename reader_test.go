@@ -0,0 +1,92 @@
+package match
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.read += n
+	return n, err
+}
+
+func TestMatchReader(t *testing.T) {
+	stream := &countingReader{r: strings.NewReader("GIF89a" + strings.Repeat(".", 1000))}
+
+	rm := MatchReader(stream).
+		When(Magic("PNG"), "png").
+		When(OneOf(Magic("GIF"), HasPrefix("BM")), "image").
+		When(ANY, "unknown")
+
+	matched, res, err := rm.Result()
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "image", res)
+	assert.Equal(t, 8, stream.read)
+
+	data, _ := io.ReadAll(rm.Reader())
+	assert.Equal(t, 1006, len(data))
+	assert.Equal(t, "GIF89a..", string(data[:8]))
+}
+
+func TestMatchReader_ShortStream(t *testing.T) {
+	rm := MatchReader(strings.NewReader("{}")).
+		When(IsGzip, "gzip").
+		When(SniffedContent("application/json"), "json")
+
+	_, res, err := rm.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "json", res)
+
+	data, _ := io.ReadAll(rm.Reader())
+	assert.Equal(t, "{}", string(data))
+}
+
+func TestMatchReader_Error(t *testing.T) {
+	readErr := errors.New("broken")
+
+	_, _, err := MatchReader(iotest.ErrReader(readErr)).When(IsGzip, true).Result()
+	assert.Equal(t, readErr, err)
+}
+
+func TestMatchReader_ErrorKeepsHead(t *testing.T) {
+	readErr := errors.New("broken")
+	stream := io.MultiReader(strings.NewReader("\x1f\x8b"), iotest.ErrReader(readErr))
+
+	rm := MatchReader(stream).When(SniffedContent("text/plain"), true)
+	_, _, err := rm.Result()
+	assert.Equal(t, readErr, err)
+
+	head := make([]byte, 2)
+	n, _ := rm.Reader().Read(head)
+	assert.Equal(t, "\x1f\x8b", string(head[:n]))
+}
+
+func TestMatchReader_LongJSON(t *testing.T) {
+	doc := `{"items": [` + strings.Repeat(`{"id": 1, "name": "item"}, `, 100) + `{"id": 2}]}`
+
+	_, res, err := MatchReader(strings.NewReader(doc)).
+		When(SniffedContent("application/json"), "json").
+		When(ANY, "other").
+		Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "json", res)
+
+	_, res, err = MatchReader(strings.NewReader(`{"a": 1} garbage`+strings.Repeat(" ", 600))).
+		When(SniffedContent("application/json"), "json").
+		When(ANY, "other").
+		Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "other", res)
+}
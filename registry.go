@@ -0,0 +1,191 @@
+package match
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// PatternChecker is func for checking pattern.
+type PatternChecker func(pattern interface{}, value interface{}) bool
+
+// ValuePatternChecker is func for checking pattern against an already reflected value.
+type ValuePatternChecker func(pattern interface{}, value reflect.Value) bool
+
+// ErrorPatternChecker is func for checking pattern which can report invalid pattern usage.
+type ErrorPatternChecker func(pattern interface{}, value interface{}) (bool, error)
+
+// CheckResult is the result of a tri-state custom pattern check.
+type CheckResult int
+
+const (
+	// NotApplicable means the checker has no opinion, so the next checkers and
+	// the built-in patterns are tried.
+	NotApplicable CheckResult = iota
+	// Matched means the value matches the pattern.
+	Matched
+	// NotMatched means the value definitely doesn't match the pattern.
+	NotMatched
+)
+
+// TriStatePatternChecker is func for checking pattern which can also veto a match.
+type TriStatePatternChecker func(pattern interface{}, value interface{}) CheckResult
+
+const (
+	// PriorityLow is the priority for checkers which run after the default ones.
+	PriorityLow = -100
+	// PriorityDefault is the priority of checkers registered by RegisterMatcher.
+	PriorityDefault = 0
+	// PriorityHigh is the priority for checkers which run before the default ones.
+	PriorityHigh = 100
+)
+
+type registeredMatcher struct {
+	priority int
+	// valueType limits the checker to values of this type, nil means any value
+	valueType reflect.Type
+	check     func(pattern interface{}, value interface{}, reflectedValue reflect.Value) (CheckResult, error)
+}
+
+var (
+	registeredMatchersMu sync.RWMutex
+	registeredMatchers   []registeredMatcher
+	// registeredByType caches the registered matchers which apply to values of a type
+	registeredByType = new(sync.Map)
+)
+
+// RegisterMatcher register custom pattern.
+func RegisterMatcher(pattern PatternChecker) {
+	register(registeredMatcher{
+		priority: PriorityDefault,
+		check: func(p interface{}, value interface{}, _ reflect.Value) (CheckResult, error) {
+			return boolToCheckResult(pattern(p, value)), nil
+		},
+	})
+}
+
+// RegisterMatcherFor register custom pattern which is checked only for values of type T.
+func RegisterMatcherFor[T any](pattern func(pattern interface{}, value T) bool) {
+	register(registeredMatcher{
+		priority:  PriorityDefault,
+		valueType: reflect.TypeOf((*T)(nil)).Elem(),
+		check: func(p interface{}, value interface{}, _ reflect.Value) (CheckResult, error) {
+			return boolToCheckResult(pattern(p, value.(T))), nil
+		},
+	})
+}
+
+// RegisterValueMatcher register custom pattern which receives the value as reflect.Value.
+// A reflect.Value passed to Match is passed to the pattern as is.
+func RegisterValueMatcher(pattern ValuePatternChecker) {
+	register(registeredMatcher{
+		priority: PriorityDefault,
+		check: func(p interface{}, _ interface{}, reflectedValue reflect.Value) (CheckResult, error) {
+			return boolToCheckResult(pattern(p, reflectedValue)), nil
+		},
+	})
+}
+
+// RegisterMatcherWithPriority register tri-state custom pattern with the given priority.
+// Checkers with higher priority run first and the first one which returns
+// Matched or NotMatched decides the result of the pattern.
+func RegisterMatcherWithPriority(priority int, pattern TriStatePatternChecker) {
+	register(registeredMatcher{
+		priority: priority,
+		check: func(p interface{}, value interface{}, _ reflect.Value) (CheckResult, error) {
+			return pattern(p, value), nil
+		},
+	})
+}
+
+// RegisterMatcherE register custom pattern which can report invalid pattern usage.
+// The error is returned by ResultE, while Result panics with it.
+func RegisterMatcherE(pattern ErrorPatternChecker) {
+	register(registeredMatcher{
+		priority: PriorityDefault,
+		check: func(p interface{}, value interface{}, _ reflect.Value) (CheckResult, error) {
+			matched, err := pattern(p, value)
+			return boolToCheckResult(matched), err
+		},
+	})
+}
+
+func register(rm registeredMatcher) {
+	registeredMatchersMu.Lock()
+	defer registeredMatchersMu.Unlock()
+
+	registeredMatchers = append(registeredMatchers, rm)
+	sort.SliceStable(registeredMatchers, func(i, j int) bool {
+		return registeredMatchers[i].priority > registeredMatchers[j].priority
+	})
+	registeredByType = new(sync.Map)
+}
+
+// matchersFor returns the registered matchers which apply to values of the
+// type in the order they are checked.
+func matchersFor(valueType reflect.Type) []registeredMatcher {
+	registeredMatchersMu.RLock()
+	defer registeredMatchersMu.RUnlock()
+
+	if len(registeredMatchers) == 0 {
+		return nil
+	}
+
+	byType := registeredByType
+	if matchers, ok := byType.Load(valueType); ok {
+		return matchers.([]registeredMatcher)
+	}
+
+	var matchers []registeredMatcher
+	for _, rm := range registeredMatchers {
+		if rm.valueType == nil || valueHasType(valueType, rm.valueType) {
+			matchers = append(matchers, rm)
+		}
+	}
+	byType.Store(valueType, matchers)
+
+	return matchers
+}
+
+// checkRegisteredMatchers checks the registered matchers in order. The value
+// matchers receive the reflected value when it's valid, the reflect.Value of
+// the value otherwise.
+func checkRegisteredMatchers(pattern interface{}, value interface{}, reflected reflect.Value) (CheckResult, error) {
+	matchers := matchersFor(reflect.TypeOf(value))
+	if len(matchers) == 0 {
+		return NotApplicable, nil
+	}
+
+	if !reflected.IsValid() {
+		reflected = reflect.ValueOf(value)
+	}
+
+	for _, rm := range matchers {
+		res, err := rm.check(pattern, value, reflected)
+		if err != nil || res != NotApplicable {
+			return res, err
+		}
+	}
+
+	return NotApplicable, nil
+}
+
+func valueHasType(valueType reflect.Type, expected reflect.Type) bool {
+	if valueType == nil {
+		return false
+	}
+
+	if expected.Kind() == reflect.Interface {
+		return valueType.Implements(expected)
+	}
+
+	return valueType == expected
+}
+
+func boolToCheckResult(matched bool) CheckResult {
+	if matched {
+		return Matched
+	}
+
+	return NotApplicable
+}
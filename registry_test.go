@@ -0,0 +1,111 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// isolateRegisteredMatchers unregisters the custom matchers for the test and
+// registers them back when it finishes.
+func isolateRegisteredMatchers(t *testing.T) {
+	registeredMatchersMu.Lock()
+	saved := registeredMatchers
+	registeredMatchers = nil
+	registeredByType = new(sync.Map)
+	registeredMatchersMu.Unlock()
+
+	t.Cleanup(func() {
+		registeredMatchersMu.Lock()
+		registeredMatchers = saved
+		registeredByType = new(sync.Map)
+		registeredMatchersMu.Unlock()
+	})
+}
+
+type vetoedValue int
+
+func TestMatch_RegisterPatternWithPriorityVeto(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	RegisterMatcherWithPriority(PriorityHigh, func(pattern interface{}, value interface{}) CheckResult {
+		if v, ok := value.(vetoedValue); ok && v < 0 {
+			return NotMatched
+		}
+
+		return NotApplicable
+	})
+
+	isMatched, _ := Match(vetoedValue(-1)).
+		When(vetoedValue(-1), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+type prioritizedValue int
+
+func TestMatch_RegisterPatternWithPriorityOrder(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	var calls []string
+	RegisterMatcherWithPriority(PriorityLow, func(pattern interface{}, value interface{}) CheckResult {
+		if _, ok := value.(prioritizedValue); ok {
+			calls = append(calls, "low")
+			return Matched
+		}
+
+		return NotApplicable
+	})
+	RegisterMatcherWithPriority(PriorityHigh, func(pattern interface{}, value interface{}) CheckResult {
+		if _, ok := value.(prioritizedValue); ok {
+			calls = append(calls, "high")
+			return NotMatched
+		}
+
+		return NotApplicable
+	})
+
+	isMatched, _ := Match(prioritizedValue(1)).
+		When(prioritizedValue(1), true).
+		Result()
+
+	assert.False(t, isMatched)
+	assert.Equal(t, []string{"high"}, calls)
+}
+
+func TestMatch_RegisteredMatchersByType(t *testing.T) {
+	isolateRegisteredMatchers(t)
+	RegisterMatcherFor(func(pattern interface{}, value temperature) bool { return false })
+	RegisterMatcherFor(func(pattern interface{}, value fmt.Stringer) bool { return false })
+
+	assert.Len(t, matchersFor(reflect.TypeOf(temperature(0))), 1)
+	assert.Len(t, matchersFor(reflect.TypeOf(fahrenheit(0))), 1)
+	assert.Empty(t, matchersFor(reflect.TypeOf("")))
+
+	RegisterMatcher(func(pattern interface{}, value interface{}) bool { return false })
+
+	assert.Len(t, matchersFor(reflect.TypeOf(temperature(0))), 2)
+	assert.Len(t, matchersFor(reflect.TypeOf("")), 1)
+	assert.Len(t, matchersFor(nil), 1)
+}
+
+func TestMatch_RegisterMatcherConcurrently(t *testing.T) {
+	isolateRegisteredMatchers(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterMatcherFor(func(pattern interface{}, value vetoedValue) bool { return false })
+		}()
+		go func() {
+			defer wg.Done()
+			isMatched, _ := Match(vetoedValue(1)).When(vetoedValue(1), true).Result()
+			assert.True(t, isMatched)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,64 @@
+package match
+
+import (
+	"database/sql"
+)
+
+// MatchRow function takes a scanned database row, as map[string]interface{}
+// (column name to value) or []interface{} (values in column order), and
+// returns the Matcher for it. Numbers of different types match by value, so
+// 42 matches int64(42) returned by the driver, and []byte values are matched
+// as strings.
+func MatchRow(row interface{}) *Matcher {
+	return Match(normalizeRow(row)).WithNumericTolerance()
+}
+
+// WithNumericTolerance makes literal numbers of different types match by
+// value, e.g. 42 matches int64(42) and float64(42).
+func (matcher *Matcher) WithNumericTolerance() *Matcher {
+	matcher.state.numericTolerance = true
+
+	return matcher
+}
+
+// WithNumericTolerance makes literal numbers of different types match by value.
+func (rs *RuleSet) WithNumericTolerance() *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.WithNumericTolerance()
+
+	return rs
+}
+
+func normalizeRow(row interface{}) interface{} {
+	switch r := row.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(r))
+		for column, value := range r {
+			normalized[column] = normalizeColumn(value)
+		}
+
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(r))
+		for i, value := range r {
+			normalized[i] = normalizeColumn(value)
+		}
+
+		return normalized
+	}
+
+	return row
+}
+
+func normalizeColumn(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case sql.RawBytes:
+		return string(v)
+	}
+
+	return value
+}
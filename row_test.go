@@ -0,0 +1,50 @@
+package match
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRow_Map(t *testing.T) {
+	row := map[string]interface{}{
+		"id":     int64(42),
+		"status": []byte("active"),
+		"score":  float64(7),
+	}
+
+	_, res := MatchRow(row).
+		When(map[string]interface{}{"status": "deleted"}, "deleted").
+		When(map[string]interface{}{"id": 42, "status": "active", "score": 7}, "active").
+		Result()
+
+	assert.Equal(t, "active", res)
+}
+
+func TestMatchRow_Slice(t *testing.T) {
+	row := []interface{}{int32(1), sql.NullString{}, "x"}
+
+	isMatched, _ := MatchRow(row).
+		When([]interface{}{uint8(1), IsNull, TAIL}, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_WithoutNumericTolerance(t *testing.T) {
+	isMatched, _ := Match(map[string]interface{}{"id": int64(42)}).
+		When(map[string]interface{}{"id": 42}, true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestRuleSet_WithNumericTolerance(t *testing.T) {
+	isMatched, _ := NewRuleSet().
+		WithNumericTolerance().
+		When(1.0, true).
+		Result(1)
+
+	assert.True(t, isMatched)
+}
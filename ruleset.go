@@ -0,0 +1,253 @@
+package match
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RuleSet is a reusable set of branches which can be matched against many values.
+// It is safe for concurrent use. String values are dispatched to string literal
+// branches by an index, so many literal branches, like route keys or event
+// names, don't slow down the matching. Consecutive regexp branches are matched
+// by a single combined regexp.
+type RuleSet struct {
+	mu         sync.Mutex
+	matcher    Matcher
+	priorities []int
+	hits       []uint64
+	// order is the evaluation order of branches, it's replaced on change
+	// so Result can use it without holding the lock.
+	order    []int
+	adaptive bool
+	cache    *lruCache
+	// dispatch indexes the string literal branches, it's reset when the
+	// order changes
+	dispatch *literalDispatch
+}
+
+// NewRuleSet creates an empty RuleSet.
+func NewRuleSet() *RuleSet {
+	rs := &RuleSet{matcher: Matcher{site: coverageSite()}}
+	rs.matcher.match = rs.matcher.buildMatchFunc()
+
+	return rs
+}
+
+// When function adds new branch with the default priority (0).
+func (rs *RuleSet) When(pattern interface{}, action interface{}) *RuleSet {
+	return rs.WhenPriority(0, pattern, action)
+}
+
+// WhenPriority adds new branch with the given priority. Branches with higher
+// priority are checked first, equally-prioritized ones in the order they were added.
+func (rs *RuleSet) WhenPriority(priority int, pattern interface{}, action interface{}) *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.When(pattern, action)
+	rs.priorities = append(rs.priorities, priority)
+	rs.hits = append(rs.hits, 0)
+
+	order := make([]int, len(rs.priorities))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rs.less(order[i], order[j])
+	})
+	rs.order = order
+	rs.dispatch = nil
+
+	return rs
+}
+
+// UseMiddleware adds middleware which wraps the evaluation of every branch.
+func (rs *RuleSet) UseMiddleware(middleware func(next MatchFunc) MatchFunc) *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.UseMiddleware(middleware)
+
+	return rs
+}
+
+// Adaptive enables reordering of equally-prioritized branches by their hit
+// count, so hot branches are checked first. Branches of the same priority
+// must not overlap, otherwise a different branch may win after reordering.
+func (rs *RuleSet) Adaptive() *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.adaptive = true
+
+	return rs
+}
+
+// WithUnexported allows struct patterns to match unexported fields, see Matcher.WithUnexported.
+func (rs *RuleSet) WithUnexported() *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.WithUnexported()
+
+	return rs
+}
+
+// WithCache enables memoization of branch results for up to size recently
+// matched comparable values. Patterns of a cached RuleSet must be pure:
+// func patterns and custom matchers are not re-evaluated for cached values.
+func (rs *RuleSet) WithCache(size int) *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.cache = newLRUCache(size)
+
+	return rs
+}
+
+// Result returns the result value of matching the value against the branches.
+// Like Matcher.Result, it doesn't allocate for scalar literal branches.
+func (rs *RuleSet) Result(val interface{}) (bool, interface{}) {
+	if rv, ok := val.(reflect.Value); ok {
+		if rv.IsValid() && !rv.CanInterface() {
+			return false, nil
+		}
+
+		val = unwrapReflectValue(rv)
+	}
+
+	mi, matchedItems, matched := rs.find(val)
+	if !matched {
+		return false, nil
+	}
+
+	return true, callAction(mi.action, matchedItems)
+}
+
+func (rs *RuleSet) formatPattern() string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return "RuleSet(" + formatBranches(rs.matcher.matchItems) + ")"
+}
+
+// matchValue makes RuleSet usable as a pattern, it matches if any branch
+// matches. Actions of the branches are not called.
+func (rs *RuleSet) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	_, matchedItems, matched := rs.find(value)
+	return matchedItems, matched
+}
+
+// find returns the first matched branch.
+func (rs *RuleSet) find(val interface{}) (matchItem, []MatchItem, bool) {
+	rs.mu.Lock()
+	matchItems, order, cache, stats := rs.matcher.matchItems, rs.order, rs.cache, rs.matcher.stats
+	matchFunc := rs.matcher.matchFunc()
+	streamFunc, isStream := rs.matcher.streamMatchFunc(val)
+	if isStream {
+		matchFunc = streamFunc
+	} else if rs.canDispatch(val) {
+		if rs.dispatch == nil {
+			start := time.Now()
+			rs.dispatch = newLiteralDispatch(matchItems, order)
+			if rs.matcher.stats != nil {
+				rs.matcher.stats.compiled(time.Since(start))
+			}
+		}
+		dispatch := rs.dispatch
+		rs.mu.Unlock()
+
+		return rs.findLiteral(dispatch, matchItems, matchFunc, stats, val)
+	}
+
+	// current is the index of the evaluated branch for the cache key, it's
+	// allocated only for the cache so uncached values don't allocate
+	var current *int
+	if cache != nil && !isStream && isCacheable(val) {
+		current = new(int)
+		// the cache is the innermost func, so middleware still sees every branch
+		matchFunc = rs.matcher.wrapMatchFunc(func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+			key := cacheKey{*current, value}
+			if matchedItems, matched, ok := cache.get(key); ok {
+				return matchedItems, matched
+			}
+
+			matchedItems, matched := matchValue(&rs.matcher.state, pattern, value)
+			cache.put(key, matchedItems, matched)
+
+			return matchedItems, matched
+		})
+	}
+	rs.mu.Unlock()
+
+	for _, index := range order {
+		if current != nil {
+			*current = index
+		}
+		mi := matchItems[index]
+		matchedItems, matched := stats.matchBranch(matchItems, index, matchFunc, val)
+		if matched {
+			rs.hit(index)
+			return mi, matchedItems, true
+		}
+	}
+
+	return matchItem{}, nil, false
+}
+
+// ResultE returns the result value like Result does, but invalid pattern
+// usage is returned as *PatternError instead of panicking and *NoMatchError
+// is returned when no pattern matches.
+func (rs *RuleSet) ResultE(val interface{}) (bool, interface{}, error) {
+	rs.mu.Lock()
+	matchItems := rs.matcher.matchItems
+	rs.mu.Unlock()
+
+	return resultE(val, matchItems, func() (bool, interface{}) { return rs.Result(val) })
+}
+
+// Hits returns the number of matches per branch in the order the branches were added.
+func (rs *RuleSet) Hits() []uint64 {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return append([]uint64(nil), rs.hits...)
+}
+
+func (rs *RuleSet) hit(index int) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.hits[index]++
+	rs.matcher.hit(index)
+	if !rs.adaptive {
+		return
+	}
+
+	for pos, i := range rs.order {
+		if i != index {
+			continue
+		}
+
+		// move the branch one step ahead at a time, it keeps the order stable
+		// for branches with close hit counts
+		if pos > 0 && rs.less(index, rs.order[pos-1]) {
+			order := append([]int(nil), rs.order...)
+			order[pos], order[pos-1] = order[pos-1], order[pos]
+			rs.order = order
+			rs.dispatch = nil
+		}
+
+		return
+	}
+}
+
+func (rs *RuleSet) less(i, j int) bool {
+	if rs.priorities[i] != rs.priorities[j] {
+		return rs.priorities[i] > rs.priorities[j]
+	}
+
+	return rs.adaptive && rs.hits[i] > rs.hits[j]
+}
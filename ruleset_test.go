@@ -0,0 +1,165 @@
+package match
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSet_MatchesManyValues(t *testing.T) {
+	rs := NewRuleSet().
+		When(1, "one").
+		When(2, "two").
+		When(ANY, "many")
+
+	_, one := rs.Result(1)
+	_, two := rs.Result(2)
+	_, many := rs.Result(3)
+
+	assert.Equal(t, "one", one)
+	assert.Equal(t, "two", two)
+	assert.Equal(t, "many", many)
+	assert.Equal(t, []uint64{1, 1, 1}, rs.Hits())
+}
+
+func TestRuleSet_Priority(t *testing.T) {
+	_, res := NewRuleSet().
+		When(ANY, "any").
+		WhenPriority(1, 42, "answer").
+		Result(42)
+
+	assert.Equal(t, "answer", res)
+}
+
+func TestRuleSet_AdaptiveReordersHotBranches(t *testing.T) {
+	var evaluated []interface{}
+	rs := NewRuleSet().
+		Adaptive().
+		UseMiddleware(func(next MatchFunc) MatchFunc {
+			return func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+				evaluated = append(evaluated, pattern)
+				return next(pattern, value)
+			}
+		}).
+		When("a", 1).
+		When("b", 2).
+		When("c", 3).
+		WhenPriority(-1, ANY, 0)
+
+	for i := 0; i < 3; i++ {
+		rs.Result("c")
+	}
+
+	evaluated = nil
+	_, res := rs.Result("c")
+
+	assert.Equal(t, 3, res)
+	assert.Equal(t, []interface{}{"c"}, evaluated)
+
+	_, res = rs.Result("z")
+	assert.Equal(t, 0, res)
+}
+
+func TestRuleSet_ResultE(t *testing.T) {
+	_, _, err := NewRuleSet().
+		When([]interface{}{1, HEAD}, true).
+		ResultE([]int{1, 2})
+
+	assert.IsType(t, &PatternError{}, err)
+}
+
+func TestRuleSet_WithCache(t *testing.T) {
+	calls := 0
+	rs := NewRuleSet().
+		WithCache(2).
+		When(func(v int) bool { calls++; return v > 10 }, "big").
+		When(ANY, "small")
+
+	for i := 0; i < 3; i++ {
+		_, res := rs.Result(42)
+		assert.Equal(t, "big", res)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	rs.Result(1)
+	rs.Result(2)
+	rs.Result(42)
+
+	assert.Equal(t, 4, calls)
+}
+
+func TestRuleSet_WithCacheSkipsUncomparableValues(t *testing.T) {
+	rs := NewRuleSet().
+		WithCache(10).
+		When([]interface{}{1, TAIL}, true)
+
+	isMatched, _ := rs.Result([]int{1, 2})
+
+	assert.True(t, isMatched)
+	assert.Equal(t, 0, rs.cache.len())
+}
+
+func TestRuleSet_WithCacheSkipsNaN(t *testing.T) {
+	type point struct {
+		X, Y float64
+	}
+
+	rs := NewRuleSet().
+		WithCache(10).
+		When(ANY, true)
+
+	rs.Result(math.NaN())
+	rs.Result(point{1, math.NaN()})
+	rs.Result([2]interface{}{1, complex(math.NaN(), 0)})
+	rs.Result(point{1, 2})
+
+	assert.Equal(t, 1, rs.cache.len())
+}
+
+func TestRuleSet_AsPattern(t *testing.T) {
+	weekend := NewRuleSet().
+		When("saturday", true).
+		When("sunday", true)
+
+	_, res := Match("sunday").
+		When(weekend, "weekend").
+		When(ANY, "weekday").
+		Result()
+
+	assert.Equal(t, "weekend", res)
+}
+
+func TestMatcher_AsPattern(t *testing.T) {
+	small := Match(nil).
+		When(Between(0, 9), nil).
+		When(OneOf("s", "xs"), nil)
+
+	_, res := Match([]interface{}{"xs", 3}).
+		When([]interface{}{small, small}, "all small").
+		When(ANY, "big").
+		Result()
+
+	assert.Equal(t, "all small", res)
+}
+
+func TestRuleSet_NestedRuleSetWithOptions(t *testing.T) {
+	admin := NewRuleSet().
+		WithUnexported().
+		When(Fields{"owner": "root"}, true)
+
+	isMatched, _ := NewRuleSet().
+		When([]interface{}{admin, TAIL}, true).
+		Result([]account{{owner: "root"}, {owner: "guest"}})
+
+	assert.True(t, isMatched)
+}
+
+func TestRuleSet_ResultENoMatch(t *testing.T) {
+	_, _, err := NewRuleSet().
+		When(1, true).
+		ResultE(2)
+
+	assert.EqualError(t, err, "match: no pattern matched value 2, tried: 1")
+}
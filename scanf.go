@@ -0,0 +1,56 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+type scanfPattern struct {
+	format string
+	ptrs   []interface{}
+}
+
+// Scanf defines the pattern for strings which are scanned completely by
+// fmt.Sscanf with the format, e.g. Scanf("%d-%s", &id, &name) matches
+// "42-books". The scanned values are stored into the pointers only when the
+// string matches. The pointers are shared by all the matches, so a Scanf
+// pattern shouldn't be used concurrently.
+func Scanf(format string, ptrs ...interface{}) interface{} {
+	return scanfPattern{format, ptrs}
+}
+
+func (sp scanfPattern) formatPattern() string {
+	return "Scanf(" + strconv.Quote(sp.format) + ")"
+}
+
+func (sp scanfPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	scanned := make([]reflect.Value, len(sp.ptrs))
+	args := make([]interface{}, len(sp.ptrs), len(sp.ptrs)+1)
+	for i, ptr := range sp.ptrs {
+		ptrValue := reflect.ValueOf(ptr)
+		if ptrValue.Kind() != reflect.Ptr || ptrValue.IsNil() {
+			panic(newPatternError(sp, fmt.Sprintf("argument %d of Scanf is not a pointer", i)))
+		}
+
+		scanned[i] = reflect.New(ptrValue.Elem().Type())
+		args[i] = scanned[i].Interface()
+	}
+
+	// the extra rune is scanned only when the string has more characters
+	var extra rune
+	if n, _ := fmt.Sscanf(str, sp.format+"%c", append(args, &extra)...); n != len(sp.ptrs) {
+		return nil, false
+	}
+
+	for i, ptr := range sp.ptrs {
+		reflect.ValueOf(ptr).Elem().Set(scanned[i].Elem())
+	}
+
+	return nil, true
+}
@@ -0,0 +1,36 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanf(t *testing.T) {
+	var id int
+	var name string
+	var major, minor int
+
+	_, res := Match("42-books").
+		When(Scanf("v%d.%d", &major, &minor), "version").
+		When(Scanf("%d-%s", &id, &name), "item").
+		Result()
+
+	assert.Equal(t, "item", res)
+	assert.Equal(t, 42, id)
+	assert.Equal(t, "books", name)
+	assert.Equal(t, 0, major)
+
+	isMatched, _ := Match("v1.2 extra").When(Scanf("v%d.%d", &major, &minor), true).Result()
+	assert.False(t, isMatched)
+	assert.Equal(t, 0, major)
+
+	isMatched, _ = Match("v1.2").When(Scanf("v%d.%d", &major, &minor), true).Result()
+	assert.True(t, isMatched)
+	assert.Equal(t, []int{1, 2}, []int{major, minor})
+}
+
+func TestScanf_NotPointer(t *testing.T) {
+	_, _, err := Match("1").When(Scanf("%d", 1), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+}
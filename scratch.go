@@ -0,0 +1,83 @@
+package match
+
+import (
+	"reflect"
+	"sync"
+)
+
+// scratch holds the map iterator and the entry of a matchMap call. Matchers
+// created WithScratch reuse them across Result calls, so deep matching of
+// large maps doesn't allocate them for every map.
+type scratch struct {
+	iter reflect.MapIter
+	// key and elem are the settable copies of the current pattern map entry,
+	// they are reallocated only when the map type changes
+	key, elem reflect.Value
+}
+
+// scratchPool is shared by the goroutines matching with the same matcher,
+// every matchMap call, including the nested ones, gets its own scratch.
+type scratchPool struct {
+	pool sync.Pool
+}
+
+// WithScratch reuses the buffers of map matching across Result calls instead
+// of allocating them for every map, it's meant for very large nested values.
+// The buffers are kept until the garbage collector drops them.
+func (matcher *Matcher) WithScratch() *Matcher {
+	matcher.state.scratch = &scratchPool{}
+
+	return matcher
+}
+
+// WithScratch reuses the buffers of map matching across Result calls, see
+// Matcher.WithScratch.
+func (rs *RuleSet) WithScratch() *RuleSet {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.matcher.WithScratch()
+
+	return rs
+}
+
+func (p *scratchPool) get() *scratch {
+	if p == nil {
+		return &scratch{}
+	}
+
+	if s, ok := p.pool.Get().(*scratch); ok {
+		return s
+	}
+
+	return &scratch{}
+}
+
+// put clears the scratch, so it doesn't keep the matched values alive.
+func (p *scratchPool) put(s *scratch) {
+	if p == nil {
+		return
+	}
+
+	s.iter.Reset(reflect.Value{})
+	if s.key.IsValid() {
+		s.key.Set(reflect.Zero(s.key.Type()))
+		s.elem.Set(reflect.Zero(s.elem.Type()))
+	}
+	p.pool.Put(s)
+}
+
+// entry returns the current entry of the iterator over a map of the type.
+func (s *scratch) entry(mapType reflect.Type) (reflect.Value, reflect.Value) {
+	if !s.key.IsValid() || s.key.Type() != mapType.Key() {
+		s.key = reflect.New(mapType.Key()).Elem()
+	}
+	if !s.elem.IsValid() || s.elem.Type() != mapType.Elem() {
+		s.elem = reflect.New(mapType.Elem()).Elem()
+	}
+
+	s.key.SetIterKey(&s.iter)
+	s.elem.SetIterValue(&s.iter)
+
+	return s.key, s.elem
+}
@@ -0,0 +1,68 @@
+package match
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSet_WithScratch(t *testing.T) {
+	// a named map type isn't matched by the fast path of map[string]interface{}
+	type document map[string]interface{}
+	value := document{"meta": map[string]interface{}{"kind": "event"}}
+	for i := 0; i < 100; i++ {
+		value[fmt.Sprintf("field-%d", i)] = i
+	}
+
+	pattern := map[string]interface{}{
+		"field-7":  7,
+		"field-42": Gt(40),
+		"meta":     map[string]interface{}{"kind": OneOf("event", "command")},
+	}
+	plain := NewRuleSet().When(pattern, "matched")
+	withScratch := NewRuleSet().WithScratch().When(pattern, "matched")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, res := withScratch.Result(value)
+				assert.Equal(t, "matched", res)
+			}
+		}()
+	}
+	wg.Wait()
+
+	isMatched, _ := withScratch.Result(map[string]interface{}{"field-7": 7})
+	assert.False(t, isMatched)
+
+	allocs := testing.AllocsPerRun(20, func() { plain.Result(value) })
+	scratchAllocs := testing.AllocsPerRun(20, func() { withScratch.Result(value) })
+	assert.True(t, scratchAllocs < allocs, "%v allocs with scratch, %v without", scratchAllocs, allocs)
+}
+
+func TestMatch_MapKeyTypes(t *testing.T) {
+	type key string
+	value := map[interface{}]interface{}{1: "int", int64(2): "int64", key("k"): "key", nil: "nil"}
+
+	for _, c := range []struct {
+		pattern  interface{}
+		expected bool
+	}{
+		{map[interface{}]interface{}{1: "int", int64(2): ANY}, true},
+		{map[interface{}]interface{}{2: "int64"}, false},
+		{map[key]interface{}{"k": "key"}, true},
+		{map[string]interface{}{"k": "key"}, false},
+		{map[interface{}]interface{}{nil: "nil"}, true},
+	} {
+		isMatched, _ := Match(value).WithScratch().When(c.pattern, true).Result()
+		assert.Equal(t, c.expected, isMatched, c.pattern)
+	}
+
+	isMatched, _ := Match(map[string]int{"a": 1}).When(map[interface{}]interface{}{"a": 1, nil: ANY}, true).Result()
+	assert.False(t, isMatched)
+}
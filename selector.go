@@ -0,0 +1,140 @@
+package match
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var labelRequirementRegexp = regexp.MustCompile(`^(!?)([A-Za-z0-9][-A-Za-z0-9_./]*)\s*(?:(==|=|!=)\s*([-A-Za-z0-9_.]*)|\s(in|notin)\s*\(([^()]*)\))?$`)
+
+type labelRequirement struct {
+	key    string
+	op     string
+	values []string
+}
+
+type labelSelectorPattern struct {
+	selector     string
+	requirements []labelRequirement
+	err          error
+}
+
+// LabelSelector defines the pattern for label sets (see Labels) by the
+// Kubernetes label selector, e.g. "app in (web,api), tier!=cache, !canary".
+// Supported requirements are key, !key, key=value, key==value, key!=value,
+// key in (values) and key notin (values), all of them must be satisfied.
+// Like in Kubernetes, != and notin match label sets without the key and
+// the empty selector matches every label set.
+func LabelSelector(selector string) interface{} {
+	pattern := labelSelectorPattern{selector: selector}
+	if strings.TrimSpace(selector) == "" {
+		return pattern
+	}
+
+	for _, requirement := range splitSelector(selector) {
+		parsed, err := parseLabelRequirement(strings.TrimSpace(requirement))
+		if err != nil {
+			return labelSelectorPattern{selector: selector, err: err}
+		}
+
+		pattern.requirements = append(pattern.requirements, parsed)
+	}
+
+	return pattern
+}
+
+func (sp labelSelectorPattern) formatPattern() string {
+	return fmt.Sprintf("LabelSelector(%q)", sp.selector)
+}
+
+func (sp labelSelectorPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if sp.err != nil {
+		panic(&PatternError{Pattern: sp, Err: sp.err})
+	}
+
+	labelSet, ok := labelSetOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	for _, requirement := range sp.requirements {
+		if !requirement.matches(labelSet) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+func (lr labelRequirement) matches(labelSet map[string]string) bool {
+	value, exists := labelSet[lr.key]
+	switch lr.op {
+	case "exists":
+		return exists
+	case "!":
+		return !exists
+	case "=", "==", "in":
+		return exists && containsString(lr.values, value)
+	}
+
+	// != and notin
+	return !exists || !containsString(lr.values, value)
+}
+
+// splitSelector splits the selector by the commas which are not in parentheses.
+func splitSelector(selector string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, selector[start:])
+}
+
+func parseLabelRequirement(requirement string) (labelRequirement, error) {
+	groups := labelRequirementRegexp.FindStringSubmatch(requirement)
+	if groups == nil {
+		return labelRequirement{}, fmt.Errorf("invalid label selector requirement %q", requirement)
+	}
+
+	negated, key, op, value, setOp, values := groups[1], groups[2], groups[3], groups[4], groups[5], groups[6]
+	switch {
+	case negated != "":
+		if op != "" || setOp != "" {
+			return labelRequirement{}, fmt.Errorf("invalid label selector requirement %q", requirement)
+		}
+		return labelRequirement{key: key, op: "!"}, nil
+	case op != "":
+		return labelRequirement{key: key, op: op, values: []string{value}}, nil
+	case setOp != "":
+		var set []string
+		for _, item := range strings.Split(values, ",") {
+			set = append(set, strings.TrimSpace(item))
+		}
+		return labelRequirement{key: key, op: setOp, values: set}, nil
+	}
+
+	return labelRequirement{key: key, op: "exists"}, nil
+}
+
+func containsString(items []string, item string) bool {
+	for _, candidate := range items {
+		if candidate == item {
+			return true
+		}
+	}
+
+	return false
+}
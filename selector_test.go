@@ -0,0 +1,42 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSelector(t *testing.T) {
+	selector := LabelSelector("app in (web, api), tier!=cache, !canary, env")
+
+	matches := func(labels map[string]string) bool {
+		isMatched, _ := Match(labels).When(selector, true).Result()
+		return isMatched
+	}
+
+	assert.True(t, matches(map[string]string{"app": "web", "env": "prod"}))
+	assert.True(t, matches(map[string]string{"app": "api", "tier": "backend", "env": ""}))
+	assert.False(t, matches(map[string]string{"app": "db", "env": "prod"}))
+	assert.False(t, matches(map[string]string{"app": "web", "tier": "cache", "env": "prod"}))
+	assert.False(t, matches(map[string]string{"app": "web", "canary": "true", "env": "prod"}))
+	assert.False(t, matches(map[string]string{"app": "web"}))
+}
+
+func TestLabelSelector_EqualityAndEmpty(t *testing.T) {
+	isMatched, _ := Match(map[string]string{"app.kubernetes.io/name": "web"}).
+		When(LabelSelector("app.kubernetes.io/name==web,version notin (v1)"), true).
+		Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(map[string]string{"a": "b"}).When(LabelSelector(""), true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestLabelSelector_Invalid(t *testing.T) {
+	for _, selector := range []string{"app in web", "a=b,", "!a=b", "=b"} {
+		pattern := LabelSelector(selector)
+		_, _, err := Match(map[string]string{}).When(pattern, true).ResultE()
+		assert.IsType(t, &PatternError{}, err, selector)
+		assert.Equal(t, pattern, err.(*PatternError).Pattern, selector)
+	}
+}
@@ -0,0 +1,231 @@
+package match
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type semver struct {
+	major, minor, patch uint64
+	prerelease          []string
+}
+
+type semverComparator struct {
+	op      string
+	version semver
+}
+
+type semverRangePattern struct {
+	constraint string
+	// alternatives are joined with "||", comparators of an alternative with AND
+	alternatives [][]semverComparator
+	err          error
+}
+
+// SemverRange defines the pattern for semantic versions within the range, e.g.
+// ">=1.2.0 <2.0.0". Comparators separated by spaces must all be satisfied,
+// "||" separates alternatives. Operators are =, !=, >, >=, <, <=, ~ and ^.
+// Like in npm and Cargo, ~ allows patch changes, or minor changes when only
+// the major version is given, e.g. ~1.2.3 is ">=1.2.3 <1.3.0" and ~1 is
+// ">=1.0.0 <2.0.0", and ^ allows changes which keep the leftmost non-zero
+// part, e.g. ^1.2.3 is ">=1.2.3 <2.0.0", ^0.2.3 is ">=0.2.3 <0.3.0" and
+// ^0.0.3 is ">=0.0.3 <0.0.4". Values can be strings (with optional "v"
+// prefix) or fmt.Stringer implementations like semver structs of other
+// packages.
+func SemverRange(constraint string) interface{} {
+	pattern := semverRangePattern{constraint: constraint}
+	for _, alternative := range strings.Split(constraint, "||") {
+		var comparators []semverComparator
+		for _, field := range strings.Fields(alternative) {
+			parsed, err := parseSemverComparators(field)
+			if err != nil {
+				return semverRangePattern{constraint: constraint, err: err}
+			}
+
+			comparators = append(comparators, parsed...)
+		}
+
+		if len(comparators) == 0 {
+			return semverRangePattern{constraint: constraint, err: fmt.Errorf("empty semver range in %q", constraint)}
+		}
+
+		pattern.alternatives = append(pattern.alternatives, comparators)
+	}
+
+	return pattern
+}
+
+func (sp semverRangePattern) formatPattern() string {
+	return fmt.Sprintf("SemverRange(%q)", sp.constraint)
+}
+
+func (sp semverRangePattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if sp.err != nil {
+		panic(&PatternError{Pattern: sp, Err: sp.err})
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return nil, false
+	}
+
+	version, _, err := parseSemver(str)
+	if err != nil {
+		return nil, false
+	}
+
+	for _, comparators := range sp.alternatives {
+		if satisfiesAll(version, comparators) {
+			return nil, true
+		}
+	}
+
+	return nil, false
+}
+
+func satisfiesAll(version semver, comparators []semverComparator) bool {
+	for _, c := range comparators {
+		cmp := compareSemver(version, c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseSemverComparators parses a comparator, ~ and ^ ranges are parsed as
+// a pair of >= and < comparators.
+func parseSemverComparators(str string) ([]semverComparator, error) {
+	op := "="
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(str, candidate) {
+			op = candidate
+			str = str[len(candidate):]
+			break
+		}
+	}
+
+	version, parts, err := parseSemver(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if op != "~" && op != "^" {
+		return []semverComparator{{op, version}}, nil
+	}
+
+	// the upper bound excludes the prereleases of the next version too
+	upper := semver{prerelease: []string{"0"}}
+	switch {
+	case parts == 1 || op == "^" && version.major != 0:
+		upper.major = version.major + 1
+	case op == "~" || parts == 2 || version.minor != 0:
+		upper.major, upper.minor = version.major, version.minor+1
+	default:
+		upper.major, upper.minor, upper.patch = version.major, version.minor, version.patch+1
+	}
+
+	return []semverComparator{{">=", version}, {"<", upper}}, nil
+}
+
+// parseSemver parses "v1.2.3-rc.1+build" like versions and returns the number
+// of the given major, minor and patch parts, missing parts are zero and build
+// metadata is ignored.
+func parseSemver(str string) (semver, int, error) {
+	str = strings.TrimPrefix(str, "v")
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		str = str[:i]
+	}
+
+	var version semver
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		version.prerelease = strings.Split(str[i+1:], ".")
+		str = str[:i]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 {
+		return semver{}, 0, fmt.Errorf("invalid semver %q", str)
+	}
+
+	numbers := []*uint64{&version.major, &version.minor, &version.patch}
+	for i, part := range parts {
+		number, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, 0, fmt.Errorf("invalid semver %q", str)
+		}
+
+		*numbers[i] = number
+	}
+
+	return version, len(parts), nil
+}
+
+func compareSemver(a, b semver) int {
+	if cmp := compareOrdered(a.major, b.major); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareOrdered(a.minor, b.minor); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareOrdered(a.patch, b.patch); cmp != 0 {
+		return cmp
+	}
+
+	// a version without prerelease has higher precedence
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		if cmp := comparePrereleaseIdentifiers(a.prerelease[i], b.prerelease[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareOrdered(int64(len(a.prerelease)), int64(len(b.prerelease)))
+}
+
+func comparePrereleaseIdentifiers(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareOrdered(an, bn)
+	case aErr == nil:
+		// numeric identifiers have lower precedence
+		return -1
+	case bErr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
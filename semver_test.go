@@ -0,0 +1,94 @@
+package match
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_SemverRange(t *testing.T) {
+	_, res := Match("v1.4.2").
+		When(SemverRange(">=2.0.0"), "v2").
+		When(SemverRange(">=1.2.0 <2.0.0"), "v1").
+		Result()
+
+	assert.Equal(t, "v1", res)
+}
+
+func TestMatch_SemverRangeAlternatives(t *testing.T) {
+	isMatched, _ := Match("3.1").
+		When(SemverRange("^1.2 || ~3.1.0"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_SemverRangePrerelease(t *testing.T) {
+	isMatched, _ := Match("2.0.0-rc.1").
+		When(SemverRange(">=2.0.0"), true).
+		Result()
+
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match("2.0.0-rc.10").
+		When(SemverRange(">2.0.0-rc.2 <2.0.0"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+type pluginVersion struct{ major, minor int }
+
+func (v pluginVersion) String() string { return fmt.Sprintf("%d.%d.0", v.major, v.minor) }
+
+func TestMatch_SemverRangeStringer(t *testing.T) {
+	isMatched, _ := Match(pluginVersion{1, 9}).
+		When(SemverRange("^1.0.0"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_SemverRangeInvalid(t *testing.T) {
+	pattern := SemverRange(">=one")
+	_, _, err := Match("1.0.0").
+		When(pattern, true).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
+
+func TestMatch_SemverRangeCaretTilde(t *testing.T) {
+	for _, c := range []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"^1.2.3", "1.2.3", true},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.2", false},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "2.0.0-rc.1", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^0", "0.9.0", true},
+		{"^0", "1.0.0", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1.2", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"~0.2.3", "0.2.4", true},
+		{"~0.2.3", "0.3.0", false},
+	} {
+		isMatched, _ := Match(c.version).When(SemverRange(c.constraint), true).Result()
+		assert.Equal(t, c.expected, isMatched, c.constraint+" "+c.version)
+	}
+}
@@ -0,0 +1,209 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// seqPattern, repeatPattern, altPattern and bindPattern are items of the
+// sequence patterns. Any other pattern inside a sequence matches one element.
+type seqPattern struct {
+	items []interface{}
+}
+
+type repeatPattern struct {
+	item     interface{}
+	min, max int
+}
+
+type altPattern struct {
+	items []interface{}
+}
+
+type bindPattern struct {
+	item interface{}
+}
+
+// Seq defines the pattern for token streams, slices or channels, which consist
+// of the sequence of items. Items are element patterns (matching one element),
+// or Repeat, Many, Many1, Optional, Alt, Bind and nested Seq items. The action
+// gets the elements captured by Bind items, in order.
+//
+// A channel matched by a Matcher or RuleSet is drained once per Result, all
+// the branches see the same elements. Result blocks until the channel is
+// closed, so it never returns for a channel which isn't closed. Channels
+// nested in other values don't match.
+func Seq(items ...interface{}) interface{} {
+	return seqPattern{items}
+}
+
+// Repeat defines the sequence item repeated from min to max times, max < 0
+// means no limit.
+func Repeat(item interface{}, min int, max int) interface{} {
+	return repeatPattern{item, min, max}
+}
+
+// Many defines the sequence item repeated any number of times.
+func Many(item interface{}) interface{} {
+	return Repeat(item, 0, -1)
+}
+
+// Many1 defines the sequence item repeated at least once.
+func Many1(item interface{}) interface{} {
+	return Repeat(item, 1, -1)
+}
+
+// Optional defines the sequence item which may be missing.
+func Optional(item interface{}) interface{} {
+	return Repeat(item, 0, 1)
+}
+
+// Alt defines the sequence item matched by any of the alternatives.
+func Alt(items ...interface{}) interface{} {
+	return altPattern{items}
+}
+
+// Bind defines the sequence item which elements are passed to the action as MatchItem.
+func Bind(item interface{}) interface{} {
+	return bindPattern{item}
+}
+
+func (sp seqPattern) formatPattern() string {
+	return "Seq(" + formatList(sp.items) + ")"
+}
+
+func (rp repeatPattern) formatPattern() string {
+	return fmt.Sprintf("Repeat(%s, %d, %d)", FormatPattern(rp.item), rp.min, rp.max)
+}
+
+func (ap altPattern) formatPattern() string {
+	return "Alt(" + formatList(ap.items) + ")"
+}
+
+func (bp bindPattern) formatPattern() string {
+	return "Bind(" + FormatPattern(bp.item) + ")"
+}
+
+func (sp seqPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	elems, ok := streamElements(ms, value)
+	if !ok {
+		return nil, false
+	}
+
+	var res []MatchItem
+	matched := matchSeqItem(ms, sp, elems, 0, nil, func(pos int, captures []MatchItem) bool {
+		if pos != len(elems) {
+			return false
+		}
+
+		res = captures
+		return true
+	})
+
+	return res, matched
+}
+
+type seqContinuation func(pos int, captures []MatchItem) bool
+
+// matchSeqItem matches the item from the position and calls the continuation
+// with every possible end position until it returns true (backtracking).
+func matchSeqItem(ms *matchState, item interface{}, elems []interface{}, pos int, captures []MatchItem, k seqContinuation) bool {
+	switch it := item.(type) {
+	case seqPattern:
+		return matchSeqItems(ms, it.items, elems, pos, captures, k)
+	case altPattern:
+		for _, alternative := range it.items {
+			if matchSeqItem(ms, alternative, elems, pos, captures, k) {
+				return true
+			}
+		}
+
+		return false
+	case repeatPattern:
+		return matchRepeat(ms, it, 0, elems, pos, captures, k)
+	case bindPattern:
+		return matchSeqItem(ms, it.item, elems, pos, captures, func(end int, inner []MatchItem) bool {
+			bound := MatchItem{valueAsSlice: elems[pos:end:end]}
+			return k(end, append(append(append([]MatchItem(nil), captures...), bound), inner[len(captures):]...))
+		})
+	}
+
+	if pos >= len(elems) || !matchValueBool(ms, item, elems[pos]) {
+		return false
+	}
+
+	return k(pos+1, captures)
+}
+
+func matchSeqItems(ms *matchState, items []interface{}, elems []interface{}, pos int, captures []MatchItem, k seqContinuation) bool {
+	if len(items) == 0 {
+		return k(pos, captures)
+	}
+
+	return matchSeqItem(ms, items[0], elems, pos, captures, func(next int, captures []MatchItem) bool {
+		return matchSeqItems(ms, items[1:], elems, next, captures, k)
+	})
+}
+
+// matchRepeat is greedy: it tries one more repetition before stopping.
+func matchRepeat(ms *matchState, rp repeatPattern, count int, elems []interface{}, pos int, captures []MatchItem, k seqContinuation) bool {
+	if rp.max < 0 || count < rp.max {
+		matched := matchSeqItem(ms, rp.item, elems, pos, captures, func(next int, captures []MatchItem) bool {
+			// an item which consumes nothing would repeat forever
+			return next > pos && matchRepeat(ms, rp, count+1, elems, next, captures, k)
+		})
+		if matched {
+			return true
+		}
+	}
+
+	return count >= rp.min && k(pos, captures)
+}
+
+func streamElements(ms *matchState, value interface{}) ([]interface{}, bool) {
+	if elems, ok := value.([]interface{}); ok {
+		return elems, true
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		return sliceValueToSliceOfInterfaces(val), true
+	case reflect.Chan:
+		if ms.streams == nil || val.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, false
+		}
+
+		if elems, ok := ms.streams[value]; ok {
+			return elems, true
+		}
+
+		var elems []interface{}
+		for {
+			elem, ok := val.Recv()
+			if !ok {
+				ms.streams[value] = elems
+				return elems, true
+			}
+
+			elems = append(elems, elem.Interface())
+		}
+	}
+
+	return nil, false
+}
+
+// streamMatchFunc returns the MatchFunc for the branches of a Result call
+// when the value is a channel. Its state drains the channel once, see Seq.
+func (matcher *Matcher) streamMatchFunc(value interface{}) (MatchFunc, bool) {
+	if reflect.ValueOf(value).Kind() != reflect.Chan {
+		return nil, false
+	}
+
+	state := matcher.state
+	state.streams = map[interface{}][]interface{}{}
+
+	return matcher.wrapMatchFunc(func(pattern interface{}, value interface{}) ([]MatchItem, bool) {
+		return matchValue(&state, pattern, value)
+	}), true
+}
@@ -0,0 +1,100 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type token struct {
+	Kind string
+	Text string
+}
+
+func kind(k string) interface{} {
+	return Fields{"Kind": k}
+}
+
+func TestMatch_SeqAssignment(t *testing.T) {
+	tokens := []token{{"ident", "x"}, {"op", "="}, {"num", "1"}, {"op", "+"}, {"num", "2"}}
+
+	expr := Seq(kind("num"), Many(Seq(kind("op"), kind("num"))))
+
+	isMatched, res := Match(tokens).
+		When(Seq(Bind(kind("ident")), Fields{"Text": "="}, Bind(expr)), func(name MatchItem, value MatchItem) []int {
+			return []int{len(name.Slice()), len(value.Slice())}
+		}).
+		Result()
+
+	assert.True(t, isMatched)
+	assert.Equal(t, []int{1, 3}, res)
+}
+
+func TestMatch_SeqAltAndOptional(t *testing.T) {
+	call := Seq("call", Alt("foo", "bar"), Optional("("), Many1("arg"), Optional(")"))
+
+	var results []interface{}
+	for _, tokens := range [][]string{
+		{"call", "foo", "arg"},
+		{"call", "bar", "(", "arg", "arg", ")"},
+		{"call", "baz", "arg"},
+		{"call", "foo"},
+	} {
+		isMatched, _ := Match(tokens).When(call, true).Result()
+		results = append(results, isMatched)
+	}
+
+	assert.Equal(t, []interface{}{true, true, false, false}, results)
+}
+
+func TestMatch_SeqBacktracking(t *testing.T) {
+	_, res := Match([]int{1, 1, 1, 2}).
+		When(Seq(Bind(Many(1)), Bind(Repeat(1, 1, 1)), 2), func(ones MatchItem, last MatchItem) []int {
+			return []int{len(ones.Slice()), len(last.Slice())}
+		}).
+		Result()
+
+	assert.Equal(t, []int{2, 1}, res)
+}
+
+func TestMatch_SeqChannel(t *testing.T) {
+	ch := make(chan string, 3)
+	ch <- "begin"
+	ch <- "x"
+	ch <- "end"
+	close(ch)
+
+	isMatched, _ := Match(ch).
+		When(Seq("begin", Many(ANY), "end"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_SeqChannelDrainedOnce(t *testing.T) {
+	stream := func() chan string {
+		ch := make(chan string, 3)
+		ch <- "begin"
+		ch <- "x"
+		ch <- "end"
+		close(ch)
+		return ch
+	}
+
+	_, res := Match(stream()).
+		When(Seq("begin", "end"), "empty").
+		When(Seq("begin", Many(ANY), "end"), "block").
+		Result()
+	assert.Equal(t, "block", res)
+
+	rs := NewRuleSet().
+		When(Seq("begin", "end"), "empty").
+		When(Seq("begin", Many(ANY), "end"), "block")
+	_, res = rs.Result(stream())
+	assert.Equal(t, "block", res)
+
+	isMatched, _ := Match(struct{ Events chan string }{stream()}).
+		When(Fields{"Events": Seq("begin", Many(ANY), "end")}, true).
+		Result()
+	assert.False(t, isMatched)
+}
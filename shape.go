@@ -0,0 +1,92 @@
+package match
+
+import (
+	"reflect"
+)
+
+type shapePattern struct {
+	dims []interface{}
+}
+
+// Shape defines the pattern for rectangular two-dimensional nested slices
+// and arrays, like [][]float64, with the numbers of rows and columns
+// matching the patterns, e.g. Shape(ANY, 3).
+func Shape(rows interface{}, cols interface{}) interface{} {
+	return shapePattern{[]interface{}{rows, cols}}
+}
+
+// ShapeN defines the pattern for rectangular nested slices and arrays with as
+// many dimensions as the patterns, whose sizes match them. Ragged values
+// aren't matched, the inner sizes of empty slices are 0. Elements of
+// []interface{} are unwrapped, so decoded JSON arrays are matched too.
+func ShapeN(dims ...interface{}) interface{} {
+	return shapePattern{dims}
+}
+
+func (sp shapePattern) formatPattern() string {
+	if len(sp.dims) == 2 {
+		return "Shape(" + formatList(sp.dims) + ")"
+	}
+
+	return "ShapeN(" + formatList(sp.dims) + ")"
+}
+
+func (sp shapePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if len(sp.dims) == 0 {
+		panic(newPatternError(sp, "ShapeN needs at least one dimension"))
+	}
+
+	shape, ok := shapeOf(reflect.ValueOf(value), len(sp.dims))
+	if !ok {
+		return nil, false
+	}
+
+	for i, dim := range sp.dims {
+		if !matchValueBool(ms, dim, shape[i]) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+// shapeOf returns the sizes of the depth dimensions of the rectangular value.
+func shapeOf(value reflect.Value, depth int) ([]int, bool) {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	shape := make([]int, depth)
+	shape[0] = value.Len()
+	if depth == 1 {
+		return shape, true
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		inner, ok := shapeOf(value.Index(i), depth-1)
+		if !ok {
+			return nil, false
+		}
+
+		if i > 0 && !intsEqual(inner, shape[1:]) {
+			return nil, false
+		}
+		copy(shape[1:], inner)
+	}
+
+	return shape, true
+}
+
+func intsEqual(a []int, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return len(a) == len(b)
+}
@@ -0,0 +1,45 @@
+package match
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShape(t *testing.T) {
+	classify := func(value interface{}) interface{} {
+		_, res := Match(value).
+			When(Shape(1, ANY), "row vector").
+			When(Shape(ANY, 1), "column vector").
+			When(Shape(Between(2, 4), 3), "small").
+			When(ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "row vector", classify([][]float64{{1, 2, 3}}))
+	assert.Equal(t, "column vector", classify([][]int{{1}, {2}}))
+	assert.Equal(t, "small", classify([2][3]float64{}))
+	assert.Equal(t, "other", classify([][]float64{{1, 2, 3}, {4, 5}}))
+	assert.Equal(t, "other", classify([]float64{1, 2, 3}))
+}
+
+func TestShapeN(t *testing.T) {
+	var image interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`[[[0,0,0],[1,1,1]],[[2,2,2],[3,3,3]]]`), &image))
+
+	isMatched, _ := Match(image).When(ShapeN(2, 2, 3), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(image).When(ShapeN(ANY, ANY, 4), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match([][]float64{}).When(Shape(0, 0), true).Result()
+	assert.True(t, isMatched)
+
+	_, _, err := Match(image).When(ShapeN(), true).ResultE()
+	assert.IsType(t, &PatternError{}, err)
+
+	assert.Equal(t, "ShapeN(2, ANY, 3)", FormatPattern(ShapeN(2, ANY, 3)))
+}
@@ -0,0 +1,153 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	// SortedAsc is the pattern for slices and arrays sorted in ascending order.
+	SortedAsc interface{} = funcPattern{"SortedAsc", isSortedAsc}
+	// SortedDesc is the pattern for slices and arrays sorted in descending order.
+	SortedDesc interface{} = funcPattern{"SortedDesc", isSortedDesc}
+	// Monotonic is the pattern for slices and arrays sorted in any order.
+	Monotonic interface{} = funcPattern{"Monotonic", func(value interface{}) bool {
+		return isSortedAsc(value) || isSortedDesc(value)
+	}}
+)
+
+type windowPattern struct {
+	size    int
+	pattern interface{}
+}
+
+// Window defines the pattern which matches if any contiguous window of the
+// given size of the slice matches the pattern. The action gets the offset of
+// the first matched window followed by the items matched by the pattern.
+func Window(size int, pattern interface{}) interface{} {
+	return windowPattern{size, pattern}
+}
+
+func (wp windowPattern) formatPattern() string {
+	return fmt.Sprintf("Window(%d, %s)", wp.size, FormatPattern(wp.pattern))
+}
+
+func (wp windowPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if wp.size <= 0 {
+		panic(newPatternError(wp, "window size must be positive"))
+	}
+
+	valueSlice, ok := sliceValueOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	if valueSlice.Kind() == reflect.Array && !valueSlice.CanAddr() {
+		arrayCopy := reflect.New(valueSlice.Type()).Elem()
+		arrayCopy.Set(valueSlice)
+		valueSlice = arrayCopy
+	}
+
+	for i := 0; i+wp.size <= valueSlice.Len(); i++ {
+		matchedItems, matched := matchValue(ms, wp.pattern, valueSlice.Slice(i, i+wp.size).Interface())
+		if matched {
+			return append([]MatchItem{{value: i}}, matchedItems...), true
+		}
+	}
+
+	return nil, false
+}
+
+func isSortedAsc(value interface{}) bool {
+	return isSorted(value, func(cmp int) bool { return cmp <= 0 })
+}
+
+func isSortedDesc(value interface{}) bool {
+	return isSorted(value, func(cmp int) bool { return cmp >= 0 })
+}
+
+// isSorted checks that every pair of neighbour elements satisfies the order.
+// Elements must be numbers or strings.
+func isSorted(value interface{}, inOrder func(cmp int) bool) bool {
+	valueSlice, ok := sliceValueOf(value)
+	if !ok {
+		return false
+	}
+
+	for i := 1; i < valueSlice.Len(); i++ {
+		cmp, ok := compareValues(valueSlice.Index(i-1).Interface(), valueSlice.Index(i).Interface())
+		if !ok || !inOrder(cmp) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sliceValueOf(value interface{}) (reflect.Value, bool) {
+	valueSlice := reflect.ValueOf(value)
+	kind := valueSlice.Kind()
+
+	return valueSlice, kind == reflect.Slice || kind == reflect.Array
+}
+
+// compareValues compares numbers of any numeric kinds or strings.
+func compareValues(a interface{}, b interface{}) (int, bool) {
+	if as, ok := a.(string); ok {
+		bs, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case as < bs:
+			return -1, true
+		case as > bs:
+			return 1, true
+		}
+
+		return 0, true
+	}
+
+	return compareNumbers(a, b)
+}
+
+type elemPattern struct {
+	pattern interface{}
+	every   bool
+}
+
+// EveryElem defines the pattern for slices and arrays whose every element
+// matches the pattern, an empty slice matches.
+func EveryElem(pattern interface{}) interface{} {
+	return elemPattern{pattern, true}
+}
+
+// SomeElem defines the pattern for slices and arrays with at least one element
+// matching the pattern.
+func SomeElem(pattern interface{}) interface{} {
+	return elemPattern{pattern, false}
+}
+
+func (ep elemPattern) formatPattern() string {
+	if ep.every {
+		return "EveryElem(" + FormatPattern(ep.pattern) + ")"
+	}
+
+	return "SomeElem(" + FormatPattern(ep.pattern) + ")"
+}
+
+func (ep elemPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	valueSlice, ok := sliceValueOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	for i := 0; i < valueSlice.Len(); i++ {
+		if matchValueBool(ms, ep.pattern, valueSlice.Index(i).Interface()) != ep.every {
+			return nil, !ep.every
+		}
+	}
+
+	return nil, ep.every
+}
@@ -0,0 +1,84 @@
+package match
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_SortedAsc(t *testing.T) {
+	_, res := Match([]int{1, 2, 2, 5}).
+		When(SortedDesc, "desc").
+		When(SortedAsc, "asc").
+		Result()
+
+	assert.Equal(t, "asc", res)
+}
+
+func TestMatch_SortedDescStrings(t *testing.T) {
+	isMatched, _ := Match([3]string{"c", "b", "a"}).
+		When(SortedDesc, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_Monotonic(t *testing.T) {
+	_, res := Match([]float64{3, 1, 2}).
+		When(Monotonic, "monotonic").
+		When(ANY, "unordered").
+		Result()
+
+	assert.Equal(t, "unordered", res)
+
+	isMatched, _ := Match([]interface{}{10, 7.5, uint(3)}).
+		When(Monotonic, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_SortedNaN(t *testing.T) {
+	for _, pattern := range []interface{}{SortedAsc, SortedDesc, Monotonic} {
+		isMatched, _ := Match([]float64{1, math.NaN(), 0}).When(pattern, true).Result()
+		assert.False(t, isMatched, FormatPattern(pattern))
+	}
+}
+
+func TestMatch_SortedNotSlice(t *testing.T) {
+	isMatched, _ := Match(42).
+		When(SortedAsc, true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_Window(t *testing.T) {
+	isMatched, res := Match([]int{5, 1, 2, 3, 9}).
+		When(Window(3, []interface{}{1, 2, 3}), func(offset MatchItem) interface{} {
+			return offset.Value()
+		}).
+		Result()
+
+	assert.True(t, isMatched)
+	assert.Equal(t, 1, res)
+}
+
+func TestMatch_WindowWithCaptures(t *testing.T) {
+	_, res := Match([4]int{1, 2, 7, 4}).
+		When(Window(2, []interface{}{2, ANY}), func(offset MatchItem, next MatchItem) []interface{} {
+			return []interface{}{offset.Value(), next.Value()}
+		}).
+		Result()
+
+	assert.Equal(t, []interface{}{1, 7}, res)
+}
+
+func TestMatch_WindowNotMatched(t *testing.T) {
+	isMatched, _ := Match([]int{1, 2}).
+		When(Window(3, []interface{}{ANY, ANY, ANY}), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
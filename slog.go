@@ -0,0 +1,152 @@
+//go:build go1.21
+
+package match
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+)
+
+type logLevelPattern struct {
+	min slog.Level
+	max slog.Level
+}
+
+// LevelBetween defines the pattern for slog records and levels with the level in the range [min, max].
+func LevelBetween(min slog.Level, max slog.Level) interface{} {
+	return logLevelPattern{min, max}
+}
+
+// LevelAtLeast defines the pattern for slog records and levels with the level min or higher.
+func LevelAtLeast(min slog.Level) interface{} {
+	return logLevelPattern{min, slog.Level(math.MaxInt)}
+}
+
+func (lp logLevelPattern) formatPattern() string {
+	if lp.max == slog.Level(math.MaxInt) {
+		return "LevelAtLeast(" + lp.min.String() + ")"
+	}
+
+	return "LevelBetween(" + lp.min.String() + ", " + lp.max.String() + ")"
+}
+
+func (lp logLevelPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	var level slog.Level
+	switch v := value.(type) {
+	case slog.Level:
+		level = v
+	case slog.Record, *slog.Record:
+		level = logRecordOf(v).Level
+	default:
+		return nil, false
+	}
+
+	return nil, level >= lp.min && level <= lp.max
+}
+
+type logMessagePattern struct {
+	pattern interface{}
+}
+
+// LogMessage defines the pattern for slog records whose message matches the
+// pattern, e.g. a regexp.
+func LogMessage(pattern interface{}) interface{} {
+	return logMessagePattern{pattern}
+}
+
+func (mp logMessagePattern) formatPattern() string {
+	return "LogMessage(" + FormatPattern(mp.pattern) + ")"
+}
+
+func (mp logMessagePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	record := logRecordOf(value)
+	if record == nil {
+		return nil, false
+	}
+
+	return matchValue(ms, mp.pattern, record.Message)
+}
+
+type logAttrsPattern struct {
+	attrs map[string]interface{}
+}
+
+// LogAttrs defines the pattern for slog records having the attributes which
+// match the patterns, other attributes are allowed like for map patterns.
+// Attributes of groups are keyed by the path, e.g. "request.method".
+func LogAttrs(attrs map[string]interface{}) interface{} {
+	return logAttrsPattern{attrs}
+}
+
+func (ap logAttrsPattern) formatPattern() string {
+	return "LogAttrs(" + FormatPattern(ap.attrs) + ")"
+}
+
+func (ap logAttrsPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	record := logRecordOf(value)
+	if record == nil {
+		return nil, false
+	}
+
+	attrs := map[string]interface{}{}
+	record.Attrs(func(attr slog.Attr) bool {
+		flattenLogAttr(attrs, "", attr)
+		return true
+	})
+
+	return nil, matchMap(ms, ap.attrs, attrs)
+}
+
+type logAttrPattern struct {
+	key   string
+	value interface{}
+}
+
+// LogAttr defines the pattern for slog.Attr values with the key whose resolved
+// value matches the pattern.
+func LogAttr(key string, valuePattern interface{}) interface{} {
+	return logAttrPattern{key, valuePattern}
+}
+
+func (ap logAttrPattern) formatPattern() string {
+	return fmt.Sprintf("LogAttr(%s, %s)", strconv.Quote(ap.key), FormatPattern(ap.value))
+}
+
+func (ap logAttrPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	attr, ok := value.(slog.Attr)
+	if !ok || attr.Key != ap.key {
+		return nil, false
+	}
+
+	return matchValue(ms, ap.value, attr.Value.Resolve().Any())
+}
+
+func logRecordOf(value interface{}) *slog.Record {
+	switch v := value.(type) {
+	case slog.Record:
+		return &v
+	case *slog.Record:
+		return v
+	}
+
+	return nil
+}
+
+func flattenLogAttr(attrs map[string]interface{}, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() != slog.KindGroup {
+		attrs[prefix+attr.Key] = value.Any()
+		return
+	}
+
+	groupPrefix := prefix
+	if attr.Key != "" {
+		groupPrefix += attr.Key + "."
+	}
+
+	for _, groupAttr := range value.Group() {
+		flattenLogAttr(attrs, groupPrefix, groupAttr)
+	}
+}
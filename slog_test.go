@@ -0,0 +1,59 @@
+//go:build go1.21
+
+package match
+
+import (
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogPatterns(t *testing.T) {
+	record := slog.NewRecord(time.Now(), slog.LevelWarn, "request timed out", 0)
+	record.AddAttrs(
+		slog.Int("status", 504),
+		slog.Group("request", slog.String("method", "GET"), slog.String("path", "/users")),
+	)
+
+	route := func(record slog.Record) interface{} {
+		_, res := Match(record).
+			When(LevelAtLeast(slog.LevelError), "page").
+			When(LogAttrs(map[string]interface{}{"request.method": "POST"}), "writes").
+			When(LogAttrs(map[string]interface{}{"status": Between(500, 599), "request.path": HasPrefix("/users")}), "users down").
+			When(LogMessage(regexp.MustCompile("timed out")), "timeout").
+			When(ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "users down", route(record))
+
+	record.Level = slog.LevelError
+	assert.Equal(t, "page", route(record))
+
+	plain := slog.NewRecord(time.Now(), slog.LevelInfo, "connection timed out", 0)
+	assert.Equal(t, "timeout", route(plain))
+}
+
+func TestLevelBetween(t *testing.T) {
+	isMatched, _ := Match(slog.LevelInfo).When(LevelBetween(slog.LevelDebug, slog.LevelInfo), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(slog.LevelWarn).When(LevelBetween(slog.LevelDebug, slog.LevelInfo), true).Result()
+	assert.False(t, isMatched)
+
+	assert.Equal(t, "LevelAtLeast(WARN)", FormatPattern(LevelAtLeast(slog.LevelWarn)))
+}
+
+func TestLogAttr(t *testing.T) {
+	isMatched, _ := Match(slog.Duration("elapsed", 2*time.Second)).
+		When(LogAttr("elapsed", func(d time.Duration) bool { return d > time.Second }), true).
+		Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(slog.Int("status", 200)).When(LogAttr("code", ANY), true).Result()
+	assert.False(t, isMatched)
+}
@@ -0,0 +1,107 @@
+//go:build go1.21
+
+// Package sloghandler provides slog.Handler which routes records to other
+// handlers by match patterns, e.g. match.LevelAtLeast or match.LogAttrs.
+package sloghandler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+)
+
+// Handler sends every record to the handlers of the first route whose pattern
+// matches it, or to the default handlers when no route matches. The patterns
+// see the attributes of the record, not the ones added by WithAttrs.
+type Handler struct {
+	rules    *match.RuleSet
+	routes   [][]slog.Handler
+	defaults []slog.Handler
+}
+
+// New creates Handler without routes which sends records to the default handlers.
+func New(defaults ...slog.Handler) *Handler {
+	return &Handler{rules: match.NewRuleSet(), defaults: defaults}
+}
+
+// Route adds the route for records matching the pattern. Routes have to be
+// added before the Handler is used.
+func (h *Handler) Route(pattern interface{}, handlers ...slog.Handler) *Handler {
+	h.rules.When(pattern, len(h.routes))
+	h.routes = append(h.routes, handlers)
+
+	return h
+}
+
+// Enabled reports whether any of the handlers handles records of the level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handlers := range h.routes {
+		if anyEnabled(ctx, handlers, level) {
+			return true
+		}
+	}
+
+	return anyEnabled(ctx, h.defaults, level)
+}
+
+func anyEnabled(ctx context.Context, handlers []slog.Handler, level slog.Level) bool {
+	for _, handler := range handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handle sends the record to the handlers of the matching route.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	handlers := h.defaults
+	if matched, route := h.rules.Result(record); matched {
+		handlers = h.routes[route.(int)]
+	}
+
+	var errs []error
+	for _, handler := range handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+
+		if err := handler.Handle(ctx, record.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns Handler whose handlers have the attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+// WithGroup returns Handler whose handlers have the group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *Handler) with(wrap func(handler slog.Handler) slog.Handler) *Handler {
+	wrapAll := func(handlers []slog.Handler) []slog.Handler {
+		wrapped := make([]slog.Handler, len(handlers))
+		for i, handler := range handlers {
+			wrapped[i] = wrap(handler)
+		}
+
+		return wrapped
+	}
+
+	routes := make([][]slog.Handler, len(h.routes))
+	for i, handlers := range h.routes {
+		routes[i] = wrapAll(handlers)
+	}
+
+	// branches of the RuleSet are indexes of the routes, so it's shared
+	return &Handler{rules: h.rules, routes: routes, defaults: wrapAll(h.defaults)}
+}
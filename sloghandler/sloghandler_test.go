@@ -0,0 +1,55 @@
+//go:build go1.21
+
+package sloghandler
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+	"github.com/stretchr/testify/assert"
+)
+
+func textHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+}
+
+func TestHandler_Routes(t *testing.T) {
+	var pager, audit, rest bytes.Buffer
+	logger := slog.New(New(textHandler(&rest)).
+		Route(match.LevelAtLeast(slog.LevelError), textHandler(&pager), textHandler(&rest)).
+		Route(match.LogAttrs(map[string]interface{}{"audit": true}), textHandler(&audit)))
+
+	logger.Error("disk full")
+	logger.Info("login", "audit", true, "user", "bob")
+	logger.Info("ping")
+
+	assert.Equal(t, "level=ERROR msg=\"disk full\"\n", pager.String())
+	assert.Equal(t, "level=INFO msg=login audit=true user=bob\n", audit.String())
+	assert.Equal(t, "level=ERROR msg=\"disk full\"\nlevel=INFO msg=ping\n", rest.String())
+}
+
+func TestHandler_WithAttrsAndEnabled(t *testing.T) {
+	var slow, rest bytes.Buffer
+	handler := New(slog.NewTextHandler(&rest, &slog.HandlerOptions{Level: slog.LevelWarn})).
+		Route(match.LogMessage(regexp.MustCompile("^slow")), textHandler(&slow))
+
+	logger := slog.New(handler).With("service", "api")
+	logger.Info("slow query")
+	logger.Info("fast query")
+
+	assert.Equal(t, "level=INFO msg=\"slow query\" service=api\n", slow.String())
+	assert.Empty(t, rest.String())
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+}
@@ -0,0 +1,37 @@
+package match
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// IsNull is the pattern for nil values and NULL database values, like
+// sql.NullString{} or any other driver.Valuer returning nil.
+var IsNull interface{} = funcPattern{"IsNull", func(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	inner, ok := sqlValue(value)
+	return ok && inner == nil
+}}
+
+// sqlValue returns the inner value of driver.Valuer, e.g. the string of
+// sql.NullString or nil when it's NULL.
+func sqlValue(value interface{}) (interface{}, bool) {
+	valuer, ok := value.(driver.Valuer)
+	if !ok {
+		return nil, false
+	}
+
+	if val := reflect.ValueOf(value); val.Kind() == reflect.Ptr && val.IsNil() {
+		return nil, false
+	}
+
+	inner, err := valuer.Value()
+	if err != nil {
+		return nil, false
+	}
+
+	return inner, true
+}
@@ -0,0 +1,65 @@
+package match
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_SQLNullString(t *testing.T) {
+	_, res := Match(sql.NullString{String: "admin", Valid: true}).
+		When(IsNull, "null").
+		When("admin", "admin").
+		Result()
+
+	assert.Equal(t, "admin", res)
+}
+
+func TestMatch_SQLNull(t *testing.T) {
+	_, res := Match(sql.NullInt64{}).
+		When(int64(0), "zero").
+		When(nil, "null").
+		Result()
+
+	assert.Equal(t, "null", res)
+
+	isMatched, _ := Match(sql.NullTime{}).
+		When(IsNull, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_SQLInnerValueWithPattern(t *testing.T) {
+	isMatched, _ := Match(sql.NullInt64{Int64: 42, Valid: true}).
+		When(Between(1, 100), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_SQLStructLiteral(t *testing.T) {
+	isMatched, _ := Match(sql.NullBool{Bool: true, Valid: true}).
+		When(sql.NullBool{Bool: true, Valid: true}, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_NilValue(t *testing.T) {
+	_, res := Match(nil).
+		When(1, "one").
+		When(nil, "nil").
+		Result()
+
+	assert.Equal(t, "nil", res)
+}
+
+func TestMatch_MapWithNullColumn(t *testing.T) {
+	isMatched, _ := Match(map[string]interface{}{"name": sql.NullString{}}).
+		When(map[string]interface{}{"name": nil}, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
@@ -0,0 +1,144 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// SampleStats are the statistics of numeric samples computed by the stats
+// patterns, the action gets them as *SampleStats so it doesn't have to
+// recompute them.
+type SampleStats struct {
+	Count              int
+	Sum, Mean, StdDev  float64
+	Min, Max           float64
+	P50, P90, P95, P99 float64
+	// sorted are the samples in ascending order for Percentile
+	sorted []float64
+}
+
+// Percentile returns the p-th percentile, p within [0, 100], interpolated
+// linearly between the closest samples.
+func (s *SampleStats) Percentile(p float64) float64 {
+	if len(s.sorted) == 0 {
+		return math.NaN()
+	}
+
+	rank := p / 100 * float64(len(s.sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(s.sorted)-1 {
+		return s.sorted[len(s.sorted)-1]
+	}
+
+	if lower < 0 {
+		return s.sorted[0]
+	}
+
+	return s.sorted[lower] + (rank-float64(lower))*(s.sorted[lower+1]-s.sorted[lower])
+}
+
+func newSampleStats(samples []float64) *SampleStats {
+	s := &SampleStats{Count: len(samples), sorted: append([]float64(nil), samples...)}
+	sort.Float64s(s.sorted)
+	if s.Count == 0 {
+		return s
+	}
+
+	for _, v := range samples {
+		s.Sum += v
+	}
+	s.Mean = s.Sum / float64(s.Count)
+
+	var squares float64
+	for _, v := range samples {
+		squares += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(squares / float64(s.Count))
+
+	s.Min, s.Max = s.sorted[0], s.sorted[s.Count-1]
+	s.P50, s.P90, s.P95, s.P99 = s.Percentile(50), s.Percentile(90), s.Percentile(95), s.Percentile(99)
+
+	return s
+}
+
+type statsPattern struct {
+	name  string
+	check func(ms *matchState, stats *SampleStats) bool
+}
+
+// Stats defines the pattern for non-empty numeric slices and arrays whose
+// *SampleStats match the pattern, e.g. Stats(Fields{"P95": Gt(200), "Max": Lt(1000)}).
+func Stats(pattern interface{}) interface{} {
+	return statsPattern{"Stats(" + FormatPattern(pattern) + ")", func(ms *matchState, stats *SampleStats) bool {
+		return stats.Count > 0 && matchValueBool(ms, pattern, stats)
+	}}
+}
+
+// MeanBetween defines the pattern for non-empty numeric slices and arrays
+// whose arithmetic mean is in the range [min, max].
+func MeanBetween(min float64, max float64) interface{} {
+	return statsPattern{fmt.Sprintf("MeanBetween(%g, %g)", min, max), func(_ *matchState, stats *SampleStats) bool {
+		return stats.Count > 0 && stats.Mean >= min && stats.Mean <= max
+	}}
+}
+
+// SumGt defines the pattern for numeric slices and arrays whose sum is
+// greater than the bound.
+func SumGt(bound float64) interface{} {
+	return statsPattern{fmt.Sprintf("SumGt(%g)", bound), func(_ *matchState, stats *SampleStats) bool {
+		return stats.Sum > bound
+	}}
+}
+
+// AllWithinStdDev defines the pattern for non-empty numeric slices and
+// arrays without outliers: every sample is at most n population standard
+// deviations away from the mean.
+func AllWithinStdDev(n float64) interface{} {
+	return statsPattern{fmt.Sprintf("AllWithinStdDev(%g)", n), func(_ *matchState, stats *SampleStats) bool {
+		return stats.Count > 0 && stats.Max-stats.Mean <= n*stats.StdDev && stats.Mean-stats.Min <= n*stats.StdDev
+	}}
+}
+
+func (sp statsPattern) formatPattern() string {
+	return sp.name
+}
+
+// matchValue binds the *SampleStats of the samples for the action.
+func (sp statsPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	samples, ok := floatsOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	stats := newSampleStats(samples)
+	if !sp.check(ms, stats) {
+		return nil, false
+	}
+
+	return []MatchItem{{value: stats}}, true
+}
+
+// floatsOf converts slices and arrays of any numeric kind to float64.
+func floatsOf(value interface{}) ([]float64, bool) {
+	if samples, ok := value.([]float64); ok {
+		return samples, true
+	}
+
+	valueSlice, ok := sliceValueOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	samples := make([]float64, valueSlice.Len())
+	for i := range samples {
+		f, ok := toFloat(reflect.ValueOf(valueSlice.Index(i).Interface()))
+		if !ok {
+			return nil, false
+		}
+		samples[i] = f
+	}
+
+	return samples, true
+}
@@ -0,0 +1,62 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsPatterns(t *testing.T) {
+	classify := func(window interface{}) interface{} {
+		_, res := Match(window).
+			When(MeanBetween(0, 100), func() string { return "normal" }).
+			When(SumGt(10000), "overload").
+			When(AllWithinStdDev(1), "steady high").
+			When(ANY, "spiky").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "normal", classify([]float64{10, 20, 30}))
+	assert.Equal(t, "normal", classify([3]int{99, 100, 101}))
+	assert.Equal(t, "overload", classify([]int64{5000, 6000}))
+	assert.Equal(t, "steady high", classify([]uint16{500, 500, 500}))
+	assert.Equal(t, "spiky", classify([]float32{200, 200, 200, 200, 1000}))
+	assert.Equal(t, "spiky", classify([]float64{}))
+	assert.Equal(t, "spiky", classify([]interface{}{200, "x"}))
+}
+
+func TestSampleStats(t *testing.T) {
+	stats := newSampleStats([]float64{9, 2, 4, 4, 4, 5, 5, 7})
+
+	assert.Equal(t, 8, stats.Count)
+	assert.Equal(t, 40.0, stats.Sum)
+	assert.Equal(t, 5.0, stats.Mean)
+	assert.InDelta(t, 2, stats.StdDev, 1e-9)
+	assert.Equal(t, 2.0, stats.Min)
+	assert.Equal(t, 9.0, stats.Max)
+	assert.Equal(t, 4.5, stats.P50)
+	assert.InDelta(t, 7.6, stats.P90, 1e-9)
+	assert.Equal(t, 2.0, stats.Percentile(0))
+	assert.Equal(t, 9.0, stats.Percentile(100))
+	assert.True(t, math.IsNaN(newSampleStats(nil).Percentile(50)))
+}
+
+func TestStats_Action(t *testing.T) {
+	latencies := []int{120, 80, 95, 300, 110, 105, 90, 1000, 100, 85}
+
+	_, res := Match(latencies).
+		When(Stats(Fields{"P90": Lt(200)}), "healthy").
+		When(Stats(Fields{"P50": Lt(200), "Max": Gte(1000)}), func(stats MatchItem) string {
+			s := stats.Value().(*SampleStats)
+			return fmt.Sprintf("outliers: max %g, p99 %.1f", s.Max, s.P99)
+		}).
+		Result()
+
+	assert.Equal(t, "outliers: max 1000, p99 937.0", res)
+
+	isMatched, _ := Match([]int{}).When(Stats(ANY), true).Result()
+	assert.False(t, isMatched)
+}
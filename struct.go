@@ -0,0 +1,237 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// StructPattern is the pattern for struct values built by StructOf.
+// Fields without a pattern match any value.
+type StructPattern struct {
+	structType reflect.Type
+	fields     []fieldPattern
+}
+
+type fieldPattern struct {
+	name    string
+	pattern interface{}
+}
+
+// StructOf defines the pattern for values of the struct type T (or pointers to it).
+func StructOf[T any]() *StructPattern {
+	return &StructPattern{structType: reflect.TypeOf((*T)(nil)).Elem()}
+}
+
+// Field adds the pattern for the field with the given name.
+func (sp *StructPattern) Field(name string, pattern interface{}) *StructPattern {
+	sp.fields = append(sp.fields, fieldPattern{name, pattern})
+
+	return sp
+}
+
+func (sp *StructPattern) formatPattern() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "StructOf[%s]()", sp.structType)
+	for _, fp := range sp.fields {
+		fmt.Fprintf(&b, ".Field(%q, %s)", fp.name, FormatPattern(fp.pattern))
+	}
+
+	return b.String()
+}
+
+func (sp *StructPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	structValue, ok := structValueOf(value)
+	if !ok || structValue.Type() != sp.structType {
+		return nil, false
+	}
+
+	fields := sp.fields
+	for _, fp := range fields {
+		if isVarPattern(fp.pattern) {
+			ms, fields = scopeFields(ms, fields, false)
+			break
+		}
+	}
+
+	for _, fp := range fields {
+		if !matchField(ms, sp, structValue, fp.name, fp.pattern) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+// Fields defines the pattern for struct values of any type by field patterns.
+// Keys are field names, promoted fields of embedded structs can be used by their
+// own name or by a dotted path like "Base.ID". Structs without some of the
+// fields don't match.
+type Fields map[string]interface{}
+
+func (fields Fields) formatPattern() string {
+	return "Fields" + formatMap(reflect.ValueOf(map[string]interface{}(fields)))
+}
+
+func (fields Fields) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	structValue, ok := structValueOf(value)
+	if !ok {
+		return nil, false
+	}
+
+	for _, pattern := range fields {
+		if isVarPattern(pattern) {
+			return fields.matchScoped(ms, structValue)
+		}
+	}
+
+	for name, pattern := range fields {
+		if !matchField(ms, fields, structValue, name, pattern) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+// matchScoped matches the fields with Var bindings, see scopeFields.
+func (fields Fields) matchScoped(ms *matchState, structValue reflect.Value) ([]MatchItem, bool) {
+	list := make([]fieldPattern, 0, len(fields))
+	for name, pattern := range fields {
+		list = append(list, fieldPattern{name, pattern})
+	}
+
+	ms, list = scopeFields(ms, list, true)
+	for _, fp := range list {
+		if !matchField(ms, fields, structValue, fp.name, fp.pattern) {
+			return nil, false
+		}
+	}
+
+	return nil, true
+}
+
+func structValueOf(value interface{}) (reflect.Value, bool) {
+	structValue := reflect.ValueOf(value)
+	if structValue.Kind() == reflect.Ptr && !structValue.IsNil() {
+		structValue = structValue.Elem()
+	}
+
+	return structValue, structValue.Kind() == reflect.Struct
+}
+
+func matchField(ms *matchState, owner interface{}, structValue reflect.Value, path string, pattern interface{}) bool {
+	fieldValue, ok := lookupField(ms, owner, structValue, path)
+	if !ok {
+		return false
+	}
+
+	return matchValueBool(ms, pattern, fieldValue.Interface())
+}
+
+// lookupField resolves the dotted path of the field. It returns false when the
+// field can't be read, e.g. it's unexported or behind a nil embedded pointer,
+// or when the struct has no such field. The type of StructOf patterns is
+// known, so they panic with *PatternError for unknown fields instead.
+func lookupField(ms *matchState, owner interface{}, structValue reflect.Value, path string) (reflect.Value, bool) {
+	current := structValue
+	for _, name := range strings.Split(path, ".") {
+		if current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			current = current.Elem()
+		}
+
+		if current.Kind() != reflect.Struct {
+			return reflect.Value{}, unknownField(owner, fmt.Errorf("%s is not a struct in path %s", current.Type(), path))
+		}
+
+		field, ok := current.Type().FieldByName(name)
+		if !ok {
+			return reflect.Value{}, unknownField(owner, fmt.Errorf("%s has no field %s", current.Type(), name))
+		}
+
+		if !field.IsExported() && !ms.unexported {
+			return reflect.Value{}, false
+		}
+
+		fieldValue, err := current.FieldByIndexErr(field.Index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		if !fieldValue.CanInterface() {
+			fieldValue = readUnexported(current, field.Index)
+		}
+
+		current = fieldValue
+	}
+
+	return current, true
+}
+
+// unknownField panics with *PatternError for StructOf patterns and returns
+// false for the others.
+func unknownField(owner interface{}, err error) bool {
+	if _, ok := owner.(*StructPattern); ok {
+		panic(&PatternError{Pattern: owner, Err: err})
+	}
+
+	return false
+}
+
+// readUnexported returns the value of the unexported field which can be
+// passed to Interface. The field is read from a copy of the struct, so
+// patterns can't modify the original value through it.
+func readUnexported(structValue reflect.Value, index []int) reflect.Value {
+	structCopy := reflect.New(structValue.Type()).Elem()
+	structCopy.Set(structValue)
+
+	field := structCopy.FieldByIndex(index)
+
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+type methodPattern struct {
+	name    string
+	pattern interface{}
+}
+
+// Method defines the pattern which calls the zero-argument method with the
+// given name on the value and matches its (first) return value with the pattern.
+// Values without such method and nil pointers don't match.
+func Method(name string, pattern interface{}) interface{} {
+	return methodPattern{name, pattern}
+}
+
+func (mp methodPattern) formatPattern() string {
+	return fmt.Sprintf("Method(%q, %s)", mp.name, FormatPattern(mp.pattern))
+}
+
+func (mp methodPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	val := reflect.ValueOf(value)
+	if value == nil || val.Kind() == reflect.Ptr && val.IsNil() {
+		return nil, false
+	}
+
+	method := val.MethodByName(mp.name)
+	if !method.IsValid() {
+		// methods with pointer receiver are callable on a copy of the value
+		valueCopy := reflect.New(val.Type())
+		valueCopy.Elem().Set(val)
+		method = valueCopy.MethodByName(mp.name)
+	}
+
+	if !method.IsValid() {
+		return nil, false
+	}
+
+	if method.Type().NumIn() != 0 || method.Type().NumOut() == 0 {
+		panic(&PatternError{Pattern: mp, Err: fmt.Errorf("method %s must take no arguments and return a value", mp.name)})
+	}
+
+	return matchValue(ms, mp.pattern, method.Call(nil)[0].Interface())
+}
@@ -0,0 +1,199 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type person struct {
+	Name string
+	Age  int
+	City string
+}
+
+func TestMatch_StructOf(t *testing.T) {
+	_, res := Match(person{"alice", 30, "Berlin"}).
+		When(StructOf[person]().Field("Name", HasPrefix("b")), "b-person").
+		When(StructOf[person]().Field("Name", HasPrefix("a")).Field("Age", Between(18, 65)), "adult a-person").
+		Result()
+
+	assert.Equal(t, "adult a-person", res)
+}
+
+func TestMatch_StructOfPointer(t *testing.T) {
+	isMatched, _ := Match(&person{"bob", 70, "Paris"}).
+		When(StructOf[person]().Field("City", OneOf("Paris", "Rome")), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_StructOfDifferentType(t *testing.T) {
+	isMatched, _ := Match(TestStruct{1}).
+		When(StructOf[person](), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_StructOfUnknownField(t *testing.T) {
+	_, _, err := Match(person{}).
+		When(StructOf[person]().Field("Email", ANY), true).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+}
+
+type Base struct {
+	ID int
+}
+
+type Audit struct {
+	Author string
+}
+
+type document struct {
+	Base
+	*Audit
+	Title string
+}
+
+func TestMatch_StructOfPromotedField(t *testing.T) {
+	isMatched, _ := Match(document{Base: Base{7}, Title: "readme"}).
+		When(StructOf[document]().Field("ID", 7), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_FieldsEmbeddedPath(t *testing.T) {
+	_, res := Match(document{Base: Base{7}, Title: "readme"}).
+		When(Fields{"Base.ID": 8}, 8).
+		When(Fields{"Base.ID": 7, "Title": HasPrefix("read")}, 7).
+		Result()
+
+	assert.Equal(t, 7, res)
+}
+
+func TestMatch_FieldsEmbeddedType(t *testing.T) {
+	isMatched, _ := Match(&document{Base: Base{7}}).
+		When(Fields{"Base": Base{7}}, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_FieldsNilEmbeddedPointer(t *testing.T) {
+	_, res := Match(document{Title: "readme"}).
+		When(Fields{"Author": ANY}, "audited").
+		When(Fields{"Audit": func(a *Audit) bool { return a == nil }}, "not audited").
+		Result()
+
+	assert.Equal(t, "not audited", res)
+}
+
+type account struct {
+	owner   string
+	balance int
+	Audit
+}
+
+func TestMatch_FieldsMissingField(t *testing.T) {
+	_, res := Match(TestStruct{1}).
+		When(Fields{"Name": "x"}, "named").
+		When(Fields{"value": 1}, "valued").
+		When(ANY, "other").
+		Result()
+	assert.Equal(t, "other", res)
+
+	for i := 0; i < 50; i++ {
+		isMatched, _, err := Match(person{Name: "alice"}).
+			When(Fields{"Name": "nomatch", "Missing": 1}, true).
+			ResultE()
+		assert.False(t, isMatched)
+		assert.ErrorIs(t, err, ErrNoMatch)
+	}
+}
+
+func TestMatch_FieldsUnexportedOffByDefault(t *testing.T) {
+	isMatched, _ := Match(account{owner: "alice"}).
+		When(Fields{"owner": "alice"}, true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_FieldsWithUnexported(t *testing.T) {
+	_, res := Match(account{owner: "alice", balance: 10, Audit: Audit{"bob"}}).
+		WithUnexported().
+		When(Fields{"owner": "bob"}, "bob").
+		When(Fields{"owner": "alice", "balance": Between(1, 100), "Author": "bob"}, "alice").
+		Result()
+
+	assert.Equal(t, "alice", res)
+}
+
+func TestRuleSet_WithUnexported(t *testing.T) {
+	_, res := NewRuleSet().
+		WithUnexported().
+		When(StructOf[account]().Field("balance", 0), "empty").
+		When(ANY, "funded").
+		Result(&account{balance: 5})
+
+	assert.Equal(t, "funded", res)
+}
+
+type order struct {
+	status string
+}
+
+func (o order) Status() string { return o.status }
+
+func (o *order) IsPaid() bool { return o.status == "paid" }
+
+func (o order) Rename(string) {}
+
+func TestMatch_Method(t *testing.T) {
+	_, res := Match(order{"active"}).
+		When(Method("Status", "closed"), "closed").
+		When(Method("Status", "active"), "active").
+		Result()
+
+	assert.Equal(t, "active", res)
+}
+
+func TestMatch_MethodPointerReceiver(t *testing.T) {
+	isMatched, _ := Match(order{"paid"}).
+		When(Method("IsPaid", true), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_MethodMissing(t *testing.T) {
+	isMatched, _ := Match(42).
+		When(Method("Status", ANY), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_MethodNilPointer(t *testing.T) {
+	isMatched, _ := Match((*order)(nil)).
+		When(Method("Status", ANY), true).
+		When(Method("IsPaid", ANY), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_MethodWithArguments(t *testing.T) {
+	pattern := Method("Rename", ANY)
+	_, _, err := Match(order{}).
+		When(pattern, true).
+		ResultE()
+
+	assert.IsType(t, &PatternError{}, err)
+	assert.Equal(t, pattern, err.(*PatternError).Pattern)
+}
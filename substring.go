@@ -0,0 +1,132 @@
+package match
+
+// ahoCorasick is the automaton searching for many substrings in one pass.
+type ahoCorasick struct {
+	nodes   []acNode
+	needles []string
+}
+
+type acNode struct {
+	next map[byte]int
+	fail int
+	// match is the index of a needle ending at the node, directly or by the
+	// fail links, or -1
+	match int
+}
+
+func newAhoCorasick(needles []string) *ahoCorasick {
+	ac := &ahoCorasick{nodes: []acNode{{next: map[byte]int{}, match: -1}}, needles: needles}
+	for i, needle := range needles {
+		state := 0
+		for j := 0; j < len(needle); j++ {
+			next, ok := ac.nodes[state].next[needle[j]]
+			if !ok {
+				next = len(ac.nodes)
+				ac.nodes = append(ac.nodes, acNode{next: map[byte]int{}, match: -1})
+				ac.nodes[state].next[needle[j]] = next
+			}
+			state = next
+		}
+
+		if ac.nodes[state].match < 0 {
+			ac.nodes[state].match = i
+		}
+	}
+
+	// fail links are set breadth-first, so the ones of shorter prefixes are ready
+	queue := make([]int, 0, len(ac.nodes))
+	for _, child := range ac.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for c, child := range ac.nodes[state].next {
+			fail := ac.nodes[state].fail
+			for fail > 0 && !ac.hasNext(fail, c) {
+				fail = ac.nodes[fail].fail
+			}
+
+			// the longest suffix which is a prefix of a needle, a missing
+			// transition is 0 which is the root
+			fail = ac.nodes[fail].next[c]
+
+			ac.nodes[child].fail = fail
+			if ac.nodes[child].match < 0 {
+				ac.nodes[child].match = ac.nodes[fail].match
+			}
+
+			queue = append(queue, child)
+		}
+	}
+
+	return ac
+}
+
+func (ac *ahoCorasick) hasNext(state int, c byte) bool {
+	_, ok := ac.nodes[state].next[c]
+	return ok
+}
+
+// find returns the index of the needle ending first in the text, or -1.
+func (ac *ahoCorasick) find(text string) int {
+	if m := ac.nodes[0].match; m >= 0 {
+		return m
+	}
+
+	state := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		for state > 0 && !ac.hasNext(state, c) {
+			state = ac.nodes[state].fail
+		}
+
+		if next, ok := ac.nodes[state].next[c]; ok {
+			state = next
+		}
+
+		if m := ac.nodes[state].match; m >= 0 {
+			return m
+		}
+	}
+
+	return -1
+}
+
+type anySubstringPattern struct {
+	automaton *ahoCorasick
+}
+
+// AnySubstring defines the pattern for strings and []byte containing any of
+// the substrings. All of them are searched for in a single pass over the
+// value by the Aho-Corasick automaton built once, so hundreds of substrings
+// cost about as much as one. The action gets the substring found first, the
+// one ending earliest in the value.
+func AnySubstring(substrings ...string) interface{} {
+	return anySubstringPattern{newAhoCorasick(append([]string(nil), substrings...))}
+}
+
+func (sp anySubstringPattern) formatPattern() string {
+	return "AnySubstring(" + formatStrings(sp.automaton.needles) + ")"
+}
+
+func (sp anySubstringPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return nil, false
+	}
+
+	i := sp.automaton.find(text)
+	if i < 0 {
+		return nil, false
+	}
+
+	return []MatchItem{{value: sp.automaton.needles[i]}}, true
+}
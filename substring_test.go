@@ -0,0 +1,51 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnySubstring(t *testing.T) {
+	classify := func(line interface{}) interface{} {
+		_, res := Match(line).
+			When(AnySubstring("panic:", "fatal error", "segfault"), func(found MatchItem) string {
+				return "crash: " + found.Value().(string)
+			}).
+			When(AnySubstring("timeout", "deadline exceeded"), "slow").
+			When(ANY, "info").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "crash: fatal error", classify("runtime: fatal error: out of memory"))
+	assert.Equal(t, "crash: panic:", classify([]byte("goroutine 1 panic: nil map")))
+	assert.Equal(t, "slow", classify("context deadline exceeded"))
+	assert.Equal(t, "info", classify("request served"))
+	assert.Equal(t, "info", classify(42))
+}
+
+func TestAhoCorasick(t *testing.T) {
+	ac := newAhoCorasick([]string{"he", "she", "his", "hers", "ushers"})
+
+	assert.Equal(t, 1, ac.find("ushe"))
+	assert.Equal(t, 0, ac.find("xhex"))
+	assert.Equal(t, 2, ac.find("ahisb"))
+	assert.Equal(t, -1, ac.find("hxs"))
+	assert.Equal(t, 0, newAhoCorasick([]string{"", "a"}).find("b"))
+
+	// compare with strings.Contains on the overlapping needles
+	needles := []string{"aab", "aba", "bab", "bb", "abba"}
+	ac = newAhoCorasick(needles)
+	for i := 0; i < 256; i++ {
+		text := strings.NewReplacer("0", "a", "1", "b").Replace(fmt.Sprintf("%08b", i))
+		contains := false
+		for _, needle := range needles {
+			contains = contains || strings.Contains(text, needle)
+		}
+
+		assert.Equal(t, contains, ac.find(text) >= 0, text)
+	}
+}
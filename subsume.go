@@ -0,0 +1,292 @@
+package match
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// Subsumes reports whether every value matched by the pattern p2 is matched
+// by the pattern p1 with the default options, registered matchers are not
+// taken into account. It's conservative: patterns it can't reason about,
+// like func patterns or custom matchers, are never subsumed except by ANY.
+func Subsumes(p1, p2 interface{}) bool {
+	if p1 == ANY {
+		return true
+	}
+
+	if c, ok := p2.(oneOfContainer); ok {
+		for _, item := range c.items {
+			if !Subsumes(p1, item) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if c, ok := p1.(oneOfContainer); ok {
+		for _, item := range c.items {
+			if Subsumes(item, p2) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if isLiteralPattern(p1) || isLiteralPattern(p2) {
+		if isLiteralPattern(p1) && isLiteralPattern(p2) {
+			return literalsEqual(p1, p2)
+		}
+
+		if in, ok := typeCheckPattern(p1); ok && isLiteralPattern(p2) {
+			return in.AssignableTo(reflect.TypeOf(p2))
+		}
+
+		return false
+	}
+
+	if r1, ok := p1.(*regexp.Regexp); ok {
+		r2, ok := p2.(*regexp.Regexp)
+		return ok && r1.String() == r2.String()
+	}
+
+	if in1, ok := typeCheckPattern(p1); ok {
+		in2, ok := typeCheckPattern(p2)
+		return ok && in1 == in2
+	}
+
+	t1, t2 := reflect.TypeOf(p1), reflect.TypeOf(p2)
+	if t1 == nil || t2 == nil {
+		return false
+	}
+
+	if t1.Kind() == reflect.Slice && t2.Kind() == reflect.Slice {
+		return sliceSubsumes(reflect.ValueOf(p1), reflect.ValueOf(p2))
+	}
+
+	if t1.Kind() == reflect.Map && t2.Kind() == reflect.Map {
+		return mapSubsumes(reflect.ValueOf(p1), reflect.ValueOf(p2))
+	}
+
+	return false
+}
+
+// Overlaps reports whether some value may be matched by both patterns with
+// the default options. It's conservative the other way round than Subsumes:
+// false means the patterns are disjoint, patterns it can't reason about
+// are considered overlapping.
+func Overlaps(p1, p2 interface{}) bool {
+	if Subsumes(p1, p2) || Subsumes(p2, p1) {
+		return true
+	}
+
+	if c, ok := p1.(oneOfContainer); ok {
+		return anyOverlaps(c.items, p2)
+	}
+
+	if c, ok := p2.(oneOfContainer); ok {
+		return anyOverlaps(c.items, p1)
+	}
+
+	class1, class2 := patternClass(p1), patternClass(p2)
+	if class1 != unknownClass && class2 != unknownClass && class1 != class2 {
+		return false
+	}
+
+	switch {
+	case isLiteralPattern(p1) && isLiteralPattern(p2):
+		return literalsEqual(p1, p2)
+	case isLiteralPattern(p1):
+		return literalOverlaps(p1, p2)
+	case isLiteralPattern(p2):
+		return literalOverlaps(p2, p1)
+	}
+
+	if in1, ok := typeCheckPattern(p1); ok {
+		if in2, ok := typeCheckPattern(p2); ok {
+			return in1 == in2 || in1.Kind() == reflect.Interface || in2.Kind() == reflect.Interface
+		}
+	}
+
+	if class1 == sliceClass && class2 == sliceClass {
+		return sliceOverlaps(reflect.ValueOf(p1), reflect.ValueOf(p2))
+	}
+
+	if class1 == mapClass && class2 == mapClass {
+		return mapOverlaps(reflect.ValueOf(p1), reflect.ValueOf(p2))
+	}
+
+	return true
+}
+
+func anyOverlaps(items []interface{}, pattern interface{}) bool {
+	for _, item := range items {
+		if Overlaps(item, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literalOverlaps checks the literal against the pattern which isn't a literal.
+func literalOverlaps(literal, pattern interface{}) bool {
+	if r, ok := pattern.(*regexp.Regexp); ok {
+		str, isString := literal.(string)
+		return isString && r.MatchString(str)
+	}
+
+	if in, ok := typeCheckPattern(pattern); ok {
+		return in.AssignableTo(reflect.TypeOf(literal))
+	}
+
+	return true
+}
+
+func sliceOverlaps(s1, s2 reflect.Value) bool {
+	if sliceHasHead(s1) || sliceHasHead(s2) || s1.Len() != s2.Len() {
+		return true
+	}
+
+	for i := 0; i < s1.Len(); i++ {
+		e1, e2 := s1.Index(i).Interface(), s2.Index(i).Interface()
+		if e1 == TAIL || e2 == TAIL {
+			return true
+		}
+
+		if !Overlaps(e1, e2) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mapOverlaps(m1, m2 reflect.Value) bool {
+	// keys which can't be looked up in m2 aren't constrained by it, so the
+	// patterns may overlap
+	iter := m1.MapRange()
+	for iter.Next() {
+		v2, ok := mapIndex(m2, m2.Type().Key(), iter.Key())
+		if ok && !Overlaps(iter.Value().Interface(), v2.Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type valueClass int
+
+const (
+	unknownClass valueClass = iota
+	sliceClass
+	mapClass
+	stringClass
+	numberClass
+)
+
+// patternClass returns the kind of values the pattern can match.
+func patternClass(pattern interface{}) valueClass {
+	if _, ok := pattern.(*regexp.Regexp); ok {
+		return stringClass
+	}
+
+	if pattern == nil {
+		return unknownClass
+	}
+
+	if !isLiteralPattern(pattern) {
+		switch reflect.TypeOf(pattern).Kind() {
+		case reflect.Slice:
+			return sliceClass
+		case reflect.Map:
+			return mapClass
+		}
+
+		return unknownClass
+	}
+
+	switch kind := reflect.TypeOf(pattern).Kind(); {
+	case kind == reflect.String:
+		return stringClass
+	case kind >= reflect.Int && kind <= reflect.Complex128:
+		return numberClass
+	}
+
+	return unknownClass
+}
+
+func sliceSubsumes(s1, s2 reflect.Value) bool {
+	if sliceHasHead(s1) || sliceHasHead(s2) {
+		return false
+	}
+
+	// the last element of a slice pattern is repeated for the rest of the
+	// values, so patterns of the same length are compared element-wise
+	for i := 0; i < s1.Len(); i++ {
+		e1 := s1.Index(i).Interface()
+		if e1 == TAIL {
+			return s2.Len() > i
+		}
+
+		if i >= s2.Len() {
+			return false
+		}
+
+		e2 := s2.Index(i).Interface()
+		if e2 == TAIL || !Subsumes(e1, e2) {
+			return false
+		}
+	}
+
+	return s1.Len() == s2.Len()
+}
+
+func mapSubsumes(m1, m2 reflect.Value) bool {
+	// a map pattern matches the maps containing all its keys, so p2 has to
+	// constrain every key of p1 at least as strict
+	iter := m1.MapRange()
+	for iter.Next() {
+		v2, ok := mapIndex(m2, m2.Type().Key(), iter.Key())
+		if !ok || !Subsumes(iter.Value().Interface(), v2.Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sliceHasHead(s reflect.Value) bool {
+	return s.Len() > 0 && s.Index(0).Interface() == HEAD
+}
+
+// isLiteralPattern reports whether the pattern is matched by equality.
+func isLiteralPattern(pattern interface{}) bool {
+	switch pattern.(type) {
+	case nil, matchKey, customPattern, Pattern, *regexp.Regexp:
+		return false
+	}
+
+	switch reflect.TypeOf(pattern).Kind() {
+	case reflect.Func, reflect.Slice, reflect.Map:
+		return false
+	}
+
+	return reflect.TypeOf(pattern).Comparable()
+}
+
+func literalsEqual(p1, p2 interface{}) bool {
+	return reflect.TypeOf(p1) == reflect.TypeOf(p2) && p1 == p2
+}
+
+// typeCheckPattern returns the checked type of the pattern like func(int) {}.
+func typeCheckPattern(pattern interface{}) (reflect.Type, bool) {
+	t := reflect.TypeOf(pattern)
+	if t == nil || t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 0 {
+		return nil, false
+	}
+
+	return t.In(0), true
+}
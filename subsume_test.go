@@ -0,0 +1,46 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubsumes(t *testing.T) {
+	assert.True(t, Subsumes(ANY, []interface{}{1, 2}))
+	assert.True(t, Subsumes(OneOf(1, 2), 2))
+	assert.True(t, Subsumes(OneOf(1, 2, 3), OneOf(3, 1)))
+	assert.True(t, Subsumes([]interface{}{1, TAIL}, []interface{}{1, 2, 3}))
+	assert.True(t, Subsumes(map[string]interface{}{"a": ANY}, map[string]interface{}{"a": 1, "b": 2}))
+	assert.True(t, Subsumes(func(int) {}, 5))
+
+	assert.False(t, Subsumes(1, ANY))
+	assert.False(t, Subsumes(1, int64(1)))
+	assert.False(t, Subsumes(OneOf(1, 2), OneOf(2, 3)))
+	assert.False(t, Subsumes([]interface{}{1, 2}, []interface{}{1, 2, 3}))
+	assert.False(t, Subsumes(map[string]interface{}{"a": 1}, map[string]interface{}{"b": 1}))
+	assert.False(t, Subsumes(map[string]int{"a": 1}, map[int]int{1: 1}))
+	assert.True(t, Subsumes(map[interface{}]interface{}{"a": ANY}, map[string]interface{}{"a": 1}))
+	assert.False(t, Subsumes(func(i int) bool { return true }, 1))
+}
+
+func TestOverlaps(t *testing.T) {
+	assert.True(t, Overlaps(ANY, 1))
+	assert.True(t, Overlaps(OneOf(1, 2), OneOf(2, 3)))
+	assert.True(t, Overlaps(regexp.MustCompile("^a"), "abc"))
+	assert.True(t, Overlaps([]interface{}{1, ANY}, []interface{}{ANY, 2}))
+	assert.True(t, Overlaps(func(i int) bool { return i > 0 }, 1))
+	assert.True(t, Overlaps(map[interface{}]interface{}{"a": 1}, map[string]interface{}{"a": 1}))
+	assert.True(t, Overlaps(map[string]int{"a": 1}, map[int]int{1: 2}))
+
+	assert.False(t, Overlaps(1, 2))
+	assert.False(t, Overlaps(OneOf(1, 2), OneOf(3, 4)))
+	assert.False(t, Overlaps(regexp.MustCompile("^a"), "bc"))
+	assert.False(t, Overlaps("1", 1))
+	assert.False(t, Overlaps([]interface{}{1, ANY}, []interface{}{2, ANY}))
+	assert.False(t, Overlaps([]interface{}{1}, map[string]interface{}{}))
+	assert.False(t, Overlaps(map[string]interface{}{"a": 1}, map[string]interface{}{"a": 2, "b": 3}))
+	assert.False(t, Overlaps(map[interface{}]interface{}{"a": 1}, map[string]interface{}{"a": 2}))
+	assert.False(t, Overlaps(func(int) {}, func(string) {}))
+}
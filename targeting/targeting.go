@@ -0,0 +1,206 @@
+// Package targeting provides feature flags whose variants are targeted by
+// rules on the user context, serialized as JSON:
+//
+//	{
+//	  "key": "new-checkout",
+//	  "default": "off",
+//	  "rules": [
+//	    {"variant": "on", "when": {"country": {"in": ["US", "CA"]}, "plan": "pro"}},
+//	    {"variant": "on", "when": {"app_version": {"semver": ">=2.1.0"}}, "rollout": 25},
+//	    {"variant": "beta", "when": {"email": {"glob": "*@example.com"}}}
+//	  ]
+//	}
+//
+// The conditions of a rule must all match, a plain value is matched by
+// equality, numbers of any types by value. The operators are in, glob,
+// regexp, semver, gte and lte.
+// The rollout is the percentage of the users in the rule, the users are
+// assigned to the buckets by the hash of the flag key and the user id.
+package targeting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"regexp"
+
+	match "github.com/alexpantyukhin/go-pattern-match"
+)
+
+// DefaultBucketBy is the key of the user context used for rollouts by default.
+const DefaultBucketBy = "id"
+
+// Flag is a feature flag compiled from the rules. It is safe for concurrent use.
+type Flag struct {
+	Key      string
+	Default  string
+	BucketBy string
+	rules    *match.RuleSet
+}
+
+type flagSpec struct {
+	Key      string     `json:"key"`
+	Default  string     `json:"default"`
+	BucketBy string     `json:"bucketBy"`
+	Rules    []ruleSpec `json:"rules"`
+}
+
+type ruleSpec struct {
+	Variant string                     `json:"variant"`
+	When    map[string]json.RawMessage `json:"when"`
+	Rollout *float64                   `json:"rollout"`
+}
+
+// Parse compiles the flag from its JSON rules.
+func Parse(data []byte) (*Flag, error) {
+	var spec flagSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("targeting: %w", err)
+	}
+
+	flag := &Flag{Key: spec.Key, Default: spec.Default, BucketBy: spec.BucketBy, rules: match.NewRuleSet().WithNumericTolerance()}
+	if flag.BucketBy == "" {
+		flag.BucketBy = DefaultBucketBy
+	}
+
+	for i, rule := range spec.Rules {
+		conditions := make(map[string]interface{}, len(rule.When))
+		for key, raw := range rule.When {
+			pattern, err := parseCondition(raw)
+			if err != nil {
+				return nil, fmt.Errorf("targeting: rule %d of flag %q, condition %q: %w", i, spec.Key, key, err)
+			}
+
+			conditions[key] = pattern
+		}
+
+		if rule.Rollout != nil && (*rule.Rollout < 0 || *rule.Rollout > 100) {
+			return nil, fmt.Errorf("targeting: rule %d of flag %q: rollout %v is out of [0, 100]", i, spec.Key, *rule.Rollout)
+		}
+
+		flag.rules.When(rulePattern{flag, conditions, rule.Rollout}, rule.Variant)
+	}
+
+	return flag, nil
+}
+
+// Variant returns the variant of the first rule matching the user context,
+// or the default variant, also when a rule fails to evaluate.
+func (f *Flag) Variant(user map[string]interface{}) string {
+	if matched, variant, err := f.rules.ResultE(user); matched && err == nil {
+		return variant.(string)
+	}
+
+	return f.Default
+}
+
+// bucket returns the rollout bucket of the user in [0, 100).
+func (f *Flag) bucket(user map[string]interface{}) (float64, bool) {
+	id, ok := user[f.BucketBy]
+	if !ok {
+		return 0, false
+	}
+
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s.%v", f.Key, id)
+
+	return float64(h.Sum32()%10000) / 100, true
+}
+
+type rulePattern struct {
+	flag       *Flag
+	conditions map[string]interface{}
+	rollout    *float64
+}
+
+func (rp rulePattern) MatchValue(value interface{}, matchFunc match.MatchFunc) ([]match.MatchItem, bool) {
+	user, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	if _, matched := matchFunc(rp.conditions, user); !matched {
+		return nil, false
+	}
+
+	if rp.rollout == nil {
+		return nil, true
+	}
+
+	bucket, ok := rp.flag.bucket(user)
+	return nil, ok && bucket < *rp.rollout
+}
+
+func parseCondition(raw json.RawMessage) (interface{}, error) {
+	var operators map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &operators); err != nil {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
+	if len(operators) != 1 {
+		return nil, fmt.Errorf("condition must have one operator, got %d", len(operators))
+	}
+
+	for op, arg := range operators {
+		return parseOperator(op, arg)
+	}
+
+	return nil, nil
+}
+
+func parseOperator(op string, arg json.RawMessage) (interface{}, error) {
+	switch op {
+	case "in":
+		var items []interface{}
+		if err := json.Unmarshal(arg, &items); err != nil {
+			return nil, err
+		}
+
+		return match.OneOf(items...), nil
+	case "gte", "lte":
+		var bound float64
+		if err := json.Unmarshal(arg, &bound); err != nil {
+			return nil, err
+		}
+
+		if op == "gte" {
+			return match.Gte(bound), nil
+		}
+
+		return match.Lte(bound), nil
+	}
+
+	var str string
+	if err := json.Unmarshal(arg, &str); err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "glob":
+		if _, err := path.Match(str, ""); err != nil {
+			return nil, err
+		}
+
+		return match.Glob(str), nil
+	case "regexp":
+		return regexp.Compile(str)
+	case "semver":
+		// an invalid range reports its error when it's matched with any version
+		pattern := match.SemverRange(str)
+		var patternErr *match.PatternError
+		if _, _, err := match.Match("0.0.0").When(pattern, nil).ResultE(); errors.As(err, &patternErr) {
+			return nil, patternErr.Err
+		}
+
+		return pattern, nil
+	}
+
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
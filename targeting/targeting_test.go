@@ -0,0 +1,63 @@
+package targeting
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const checkoutFlag = `{
+	"key": "new-checkout",
+	"default": "off",
+	"rules": [
+		{"variant": "on", "when": {"country": {"in": ["US", "CA"]}, "plan": "pro"}},
+		{"variant": "beta", "when": {"email": {"glob": "*@example.com"}}},
+		{"variant": "on", "when": {"app_version": {"semver": ">=2.1.0"}, "age": {"gte": 18}}, "rollout": 25}
+	]
+}`
+
+func TestFlag_Variant(t *testing.T) {
+	flag, err := Parse([]byte(checkoutFlag))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "on", flag.Variant(map[string]interface{}{"country": "CA", "plan": "pro"}))
+	assert.Equal(t, "off", flag.Variant(map[string]interface{}{"country": "DE", "plan": "pro"}))
+	assert.Equal(t, "beta", flag.Variant(map[string]interface{}{"email": "dev@example.com"}))
+	assert.Equal(t, "off", flag.Variant(map[string]interface{}{}))
+}
+
+func TestFlag_Rollout(t *testing.T) {
+	flag, err := Parse([]byte(checkoutFlag))
+	assert.NoError(t, err)
+
+	on := 0
+	for i := 0; i < 1000; i++ {
+		user := map[string]interface{}{"id": fmt.Sprintf("user-%d", i), "app_version": "2.3.0", "age": 30}
+		if flag.Variant(user) == "on" {
+			on++
+		}
+
+		// the assignment is stable
+		assert.Equal(t, flag.Variant(user), flag.Variant(user))
+	}
+
+	assert.InDelta(t, 250, on, 50)
+	assert.Equal(t, "off", flag.Variant(map[string]interface{}{"app_version": "2.3.0", "age": 30}))
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, data := range []string{
+		`{"rules": [{"variant": "x", "when": {"a": {"near": 1}}}]}`,
+		`{"rules": [{"variant": "x", "when": {"a": {"in": 1}}}]}`,
+		`{"rules": [{"variant": "x", "when": {"a": {"in": [1], "glob": "*"}}}]}`,
+		`{"rules": [{"variant": "x", "rollout": 120}]}`,
+		`{"rules": [{"variant": "x", "when": {"a": {"regexp": "("}}}]}`,
+		`{"rules": [{"variant": "x", "when": {"a": {"glob": "[oops"}}}]}`,
+		`{"rules": [{"variant": "x", "when": {"a": {"semver": ">=banana"}}}]}`,
+		`[]`,
+	} {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,212 @@
+package match
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type timeLayoutPattern struct {
+	layout string
+}
+
+// TimeLayout defines the pattern for strings which can be parsed with the
+// time layout, e.g. "2006-01-02". The action gets the parsed time.Time.
+func TimeLayout(layout string) interface{} {
+	return timeLayoutPattern{layout}
+}
+
+func (tp timeLayoutPattern) formatPattern() string {
+	return "TimeLayout(" + strconv.Quote(tp.layout) + ")"
+}
+
+func (tp timeLayoutPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	parsed, err := time.Parse(tp.layout, str)
+	if err != nil {
+		return nil, false
+	}
+
+	return []MatchItem{{value: parsed}}, true
+}
+
+type weekdayPattern struct {
+	pattern interface{}
+}
+
+// Weekday defines the pattern for time.Time values whose day of the week
+// matches the pattern, e.g. Weekday(OneOf(time.Saturday, time.Sunday)).
+func Weekday(pattern interface{}) interface{} {
+	return weekdayPattern{pattern}
+}
+
+func (wp weekdayPattern) formatPattern() string {
+	return "Weekday(" + FormatPattern(wp.pattern) + ")"
+}
+
+func (wp weekdayPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	t, ok := value.(time.Time)
+	if !ok {
+		return nil, false
+	}
+
+	return matchValue(ms, wp.pattern, t.Weekday())
+}
+
+// HourBetween defines the pattern for time.Time values from the hour from
+// until the hour to, excluded, in their location, so HourBetween(9, 17) matches
+// 9:00 to 16:59. The range wraps around midnight when from is greater than to,
+// e.g. HourBetween(22, 6).
+func HourBetween(from int, to int) interface{} {
+	return funcPattern{fmt.Sprintf("HourBetween(%d, %d)", from, to), func(value interface{}) bool {
+		t, ok := value.(time.Time)
+		if !ok {
+			return false
+		}
+
+		hour := t.Hour()
+		if from <= to {
+			return hour >= from && hour < to
+		}
+
+		return hour >= from || hour < to
+	}}
+}
+
+type cronPattern struct {
+	expr                              string
+	minutes, hours, days, months, dow uint64
+	// anyDay and anyDow record unrestricted fields, when both days and days
+	// of the week are restricted either of them matches like in cron
+	anyDay, anyDow bool
+	err            error
+}
+
+var (
+	cronMonths   = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+	cronWeekdays = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+)
+
+// Cron defines the pattern for time.Time values at the minutes of the cron
+// schedule, in their location. The expression has the five fields minute,
+// hour, day of month, month and day of week, each of them "*", a value, a
+// range "MON-FRI" or a list of those, optionally with a step like "*/15".
+// Months and days of the week can be names, Sunday is 0 or 7.
+func Cron(expr string) interface{} {
+	cp := cronPattern{expr: expr}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		cp.err = fmt.Errorf("cron expression %q has %d fields, expected 5", expr, len(fields))
+		return cp
+	}
+
+	for _, f := range []struct {
+		field    string
+		bits     *uint64
+		min, max int
+		names    map[string]int
+	}{
+		{fields[0], &cp.minutes, 0, 59, nil},
+		{fields[1], &cp.hours, 0, 23, nil},
+		{fields[2], &cp.days, 1, 31, nil},
+		{fields[3], &cp.months, 1, 12, cronMonths},
+		{fields[4], &cp.dow, 0, 7, cronWeekdays},
+	} {
+		if *f.bits, cp.err = parseCronField(f.field, f.min, f.max, f.names); cp.err != nil {
+			return cp
+		}
+	}
+
+	if cp.dow&(1<<7) != 0 {
+		cp.dow |= 1
+	}
+	cp.anyDay, cp.anyDow = strings.HasPrefix(fields[2], "*"), strings.HasPrefix(fields[4], "*")
+
+	return cp
+}
+
+func parseCronField(field string, min int, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			rng = part[:i]
+		}
+
+		from, to := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if from, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+
+			to = from
+			if len(bounds) == 2 {
+				if to, err = parseCronValue(bounds[1], min, max, names); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				to = max
+			}
+
+			if from > to {
+				return 0, fmt.Errorf("invalid range in cron field %q", field)
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, min int, max int, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("invalid cron value %q", value)
+	}
+
+	return n, nil
+}
+
+func (cp cronPattern) formatPattern() string {
+	return "Cron(" + strconv.Quote(cp.expr) + ")"
+}
+
+func (cp cronPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if cp.err != nil {
+		panic(&PatternError{Pattern: cp, Err: cp.err})
+	}
+
+	t, ok := value.(time.Time)
+	if !ok {
+		return nil, false
+	}
+
+	if cp.minutes&(1<<t.Minute()) == 0 || cp.hours&(1<<t.Hour()) == 0 || cp.months&(1<<t.Month()) == 0 {
+		return nil, false
+	}
+
+	dayOk, dowOk := cp.days&(1<<t.Day()) != 0, cp.dow&(1<<t.Weekday()) != 0
+	if !cp.anyDay && !cp.anyDow {
+		return nil, dayOk || dowOk
+	}
+
+	return nil, dayOk && dowOk
+}
@@ -0,0 +1,87 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_TimeLayout(t *testing.T) {
+	_, res := Match("2024-02-29").
+		When(TimeLayout(time.RFC3339), "timestamp").
+		When(TimeLayout("2006-01-02"), func(date MatchItem) interface{} {
+			return date.Value().(time.Time).YearDay()
+		}).
+		Result()
+
+	assert.Equal(t, 60, res)
+}
+
+func TestMatch_TimeLayoutNotMatched(t *testing.T) {
+	isMatched, _ := Match("2023-02-29").
+		When(TimeLayout("2006-01-02"), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
+
+func TestMatch_TimeLayoutInSlice(t *testing.T) {
+	isMatched, _ := Match([]string{"login", "2024-01-01"}).
+		When([]interface{}{"login", TimeLayout("2006-01-02")}, true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_Cron(t *testing.T) {
+	standup := Cron("0 9 * * MON-FRI")
+
+	monday := time.Date(2024, time.March, 4, 9, 0, 30, 0, time.UTC)
+	isMatched, _ := Match(monday).When(standup, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(monday.Add(time.Minute)).When(standup, true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(monday.AddDate(0, 0, 5)).When(standup, true).Result()
+	assert.False(t, isMatched)
+
+	quarterly := Cron("*/15 0-6,22 1 jan,jul *")
+	isMatched, _ = Match(time.Date(2024, time.July, 1, 22, 45, 0, 0, time.UTC)).When(quarterly, true).Result()
+	assert.True(t, isMatched)
+
+	// either the day of month or the day of week, Sunday as 7
+	either := Cron("0 0 13 * 7")
+	isMatched, _ = Match(time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)).When(either, true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(time.Date(2024, time.March, 13, 0, 0, 0, 0, time.UTC)).When(either, true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestMatch_CronInvalid(t *testing.T) {
+	for _, expr := range []string{"0 9 * *", "60 * * * *", "0 9 * * FRI-MON", "*/0 * * * *"} {
+		pattern := Cron(expr)
+		_, _, err := Match(time.Now()).When(pattern, true).ResultE()
+		assert.IsType(t, &PatternError{}, err, expr)
+		assert.Equal(t, pattern, err.(*PatternError).Pattern, expr)
+	}
+}
+
+func TestMatch_WeekdayAndHour(t *testing.T) {
+	route := func(at time.Time) interface{} {
+		_, res := Match(at).
+			When(Weekday(OneOf(time.Saturday, time.Sunday)), "weekend").
+			When(HourBetween(9, 17), "office").
+			When(HourBetween(22, 6), "night").
+			When(ANY, "evening").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "weekend", route(time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "office", route(time.Date(2024, time.March, 11, 16, 59, 0, 0, time.UTC)))
+	assert.Equal(t, "evening", route(time.Date(2024, time.March, 11, 17, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "night", route(time.Date(2024, time.March, 11, 2, 0, 0, 0, time.UTC)))
+}
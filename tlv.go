@@ -0,0 +1,210 @@
+package match
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// TLV is a type-length-value record read by TLVIterator.
+type TLV struct {
+	Type  uint32
+	Value []byte
+	// Offset is the position of the record in the data.
+	Offset int
+}
+
+// TLVFormat defines the encoding of TLV records. The zero value reads one
+// byte types followed by BER definite lengths, like DER with low tag numbers.
+type TLVFormat struct {
+	// TypeSize is the size of the type in bytes: 1, 2 or 4, 0 means 1.
+	TypeSize int
+	// LengthSize is the size of the length in bytes: 1, 2 or 4, 0 means the
+	// BER definite length (one byte below 0x80, otherwise 0x80 plus the number
+	// of the following length bytes).
+	LengthSize int
+	// Order is the byte order of the types and lengths, nil means big endian.
+	Order binary.ByteOrder
+}
+
+// TLVIterator reads the TLV records of the data one by one.
+type TLVIterator struct {
+	data   []byte
+	offset int
+	format TLVFormat
+	tlv    TLV
+	err    error
+}
+
+// TLVs returns the iterator over the TLV records of the data.
+func TLVs(data []byte, format TLVFormat) *TLVIterator {
+	if format.TypeSize == 0 {
+		format.TypeSize = 1
+	}
+
+	if format.Order == nil {
+		format.Order = binary.BigEndian
+	}
+
+	it := &TLVIterator{data: data, format: format}
+	if !validUintSize(format.TypeSize) || (format.LengthSize != 0 && !validUintSize(format.LengthSize)) {
+		it.err = fmt.Errorf("match: invalid TLV format %+v", format)
+	}
+
+	return it
+}
+
+func validUintSize(size int) bool {
+	return size == 1 || size == 2 || size == 4
+}
+
+// Next reads the next record, it returns false at the end of the data or
+// when the data is malformed, see Err.
+func (it *TLVIterator) Next() bool {
+	if it.err != nil || it.offset == len(it.data) {
+		return false
+	}
+
+	rest := it.data[it.offset:]
+	typ, ok := readUint(rest, it.format.TypeSize, it.format.Order)
+	if !ok {
+		return it.truncated()
+	}
+	rest = rest[it.format.TypeSize:]
+
+	length, n, ok := it.readLength(rest)
+	if !ok {
+		return false
+	}
+	rest = rest[n:]
+
+	if uint64(len(rest)) < length {
+		return it.truncated()
+	}
+
+	typeBits, _ := toBits(typ)
+	it.tlv = TLV{Type: uint32(typeBits), Value: rest[:length], Offset: it.offset}
+	it.offset += it.format.TypeSize + n + int(length)
+
+	return true
+}
+
+// readLength returns the length of the value and the number of bytes it was encoded in.
+func (it *TLVIterator) readLength(data []byte) (uint64, int, bool) {
+	if it.format.LengthSize != 0 {
+		length, ok := readUint(data, it.format.LengthSize, it.format.Order)
+		if !ok {
+			return 0, 0, it.truncated()
+		}
+
+		bits, _ := toBits(length)
+		return bits, it.format.LengthSize, true
+	}
+
+	if len(data) == 0 {
+		return 0, 0, it.truncated()
+	}
+
+	if data[0] < 0x80 {
+		return uint64(data[0]), 1, true
+	}
+
+	n := int(data[0] & 0x7F)
+	if n == 0 || n > 4 {
+		it.err = fmt.Errorf("match: unsupported BER length at offset %d", it.offset)
+		return 0, 0, false
+	}
+
+	if len(data) < 1+n {
+		return 0, 0, it.truncated()
+	}
+
+	var length uint64
+	for _, b := range data[1 : 1+n] {
+		length = length<<8 | uint64(b)
+	}
+
+	return length, 1 + n, true
+}
+
+func (it *TLVIterator) truncated() bool {
+	it.err = fmt.Errorf("match: truncated TLV at offset %d", it.offset)
+
+	return false
+}
+
+// TLV returns the record read by the last Next call.
+func (it *TLVIterator) TLV() TLV {
+	return it.tlv
+}
+
+// Err returns the error of malformed data.
+func (it *TLVIterator) Err() error {
+	return it.err
+}
+
+type tlvPattern struct {
+	typ     uint32
+	pattern interface{}
+}
+
+// TLVOf defines the pattern for TLV records of the type whose value matches
+// the pattern, e.g. TLVOf(0x02, Frame().Uint8(ANY)). The action gets the
+// values bound by the value pattern.
+func TLVOf(typ uint32, valuePattern interface{}) interface{} {
+	return tlvPattern{typ, valuePattern}
+}
+
+func (tp tlvPattern) formatPattern() string {
+	return fmt.Sprintf("TLVOf(%#x, %s)", tp.typ, FormatPattern(tp.pattern))
+}
+
+func (tp tlvPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	var tlv TLV
+	switch v := value.(type) {
+	case TLV:
+		tlv = v
+	case *TLV:
+		if v == nil {
+			return nil, false
+		}
+		tlv = *v
+	default:
+		return nil, false
+	}
+
+	if tlv.Type != tp.typ {
+		return nil, false
+	}
+
+	return matchValue(ms, tp.pattern, tlv.Value)
+}
+
+// ForEachTLV classifies every TLV record of the data by the branches added by
+// the build func, usually TLVOf patterns, and streams the result values of
+// the matched records to handle. Records which match no branch are passed to
+// unmatched, which can be nil. The error is returned when the data is
+// malformed or a pattern is invalid, see PatternError.
+func ForEachTLV(data []byte, format TLVFormat, build func(rules *RuleSet) *RuleSet, handle func(tlv TLV, result interface{}), unmatched func(tlv TLV)) error {
+	rules := build(NewRuleSet())
+
+	it := TLVs(data, format)
+	for it.Next() {
+		tlv := it.TLV()
+		_, res, err := rules.ResultE(tlv)
+		if errors.Is(err, ErrNoMatch) {
+			if unmatched != nil {
+				unmatched(tlv)
+			}
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		handle(tlv, res)
+	}
+
+	return it.Err()
+}
@@ -0,0 +1,46 @@
+package match
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachTLV(t *testing.T) {
+	data := []byte{
+		0x01, 0x02, 'h', 'i',
+		0x02, 0x01, 0x2A,
+		0x7F, 0x00,
+		0x01, 0x81, 0x03, 'a', 'b', 'c',
+	}
+
+	var results []interface{}
+	var unmatched []uint32
+	err := ForEachTLV(data, TLVFormat{},
+		func(rules *RuleSet) *RuleSet {
+			return rules.
+				When(TLVOf(0x01, ANY), func() string { return "text" }).
+				When(TLVOf(0x02, Frame().Uint8(ANY)), func(n MatchItem) interface{} { return n.Value() })
+		},
+		func(tlv TLV, result interface{}) { results = append(results, result) },
+		func(tlv TLV) { unmatched = append(unmatched, tlv.Type) },
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"text", uint8(42), "text"}, results)
+	assert.Equal(t, []uint32{0x7F}, unmatched)
+}
+
+func TestTLVs(t *testing.T) {
+	it := TLVs([]byte{0x00, 0x10, 0x02, 0x00, 'o', 'k', 0x00, 0x20, 0x05, 0x00}, TLVFormat{TypeSize: 2, LengthSize: 2, Order: binary.LittleEndian})
+
+	assert.True(t, it.Next())
+	assert.Equal(t, TLV{Type: 0x1000, Value: []byte("ok"), Offset: 0}, it.TLV())
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "match: truncated TLV at offset 6")
+
+	it = TLVs(nil, TLVFormat{TypeSize: 3})
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+}
@@ -0,0 +1,84 @@
+package match
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type tmplPattern struct {
+	template string
+	regexp   *regexp.Regexp
+	names    []string
+	err      error
+}
+
+// Tmpl defines the pattern for strings of the template shape, e.g.
+// Tmpl("order-${id}-${region}") matches "order-42-eu". The action gets the
+// placeholder values as map[string]string. Placeholders match at least one
+// character, as few as possible, so "order-${id}-${region}" binds "a-b-c" as
+// id "a" and region "b-c".
+func Tmpl(template string) interface{} {
+	pattern := tmplPattern{template: template}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	rest := template
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			expr.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			pattern.err = fmt.Errorf("unterminated placeholder in template %q", template)
+			return pattern
+		}
+
+		name := rest[start+2 : start+end]
+		if name == "" {
+			pattern.err = fmt.Errorf("empty placeholder in template %q", template)
+			return pattern
+		}
+
+		expr.WriteString(regexp.QuoteMeta(rest[:start]))
+		expr.WriteString("(.+?)")
+		pattern.names = append(pattern.names, name)
+		rest = rest[start+end+1:]
+	}
+	expr.WriteString("$")
+
+	pattern.regexp = regexp.MustCompile(expr.String())
+
+	return pattern
+}
+
+func (tp tmplPattern) formatPattern() string {
+	return "Tmpl(" + strconv.Quote(tp.template) + ")"
+}
+
+func (tp tmplPattern) matchValue(_ *matchState, value interface{}) ([]MatchItem, bool) {
+	if tp.err != nil {
+		panic(&PatternError{Pattern: tp, Err: tp.err})
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	groups := tp.regexp.FindStringSubmatch(str)
+	if groups == nil {
+		return nil, false
+	}
+
+	bound := make(map[string]string, len(tp.names))
+	for i, name := range tp.names {
+		bound[name] = groups[i+1]
+	}
+
+	return []MatchItem{{value: bound}}, true
+}
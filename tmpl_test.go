@@ -0,0 +1,39 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTmpl(t *testing.T) {
+	_, res := Match("order-42-eu").
+		When(Tmpl("invoice-${id}"), "invoice").
+		When(Tmpl("order-${id}-${region}"), func(params MatchItem) interface{} {
+			return params.Value()
+		}).
+		Result()
+	assert.Equal(t, map[string]string{"id": "42", "region": "eu"}, res)
+
+	_, res = Match([]interface{}{"user.v1.created", 1}).
+		When([]interface{}{Tmpl("${entity}.v1.${event}"), ANY}, func(params MatchItem, _ MatchItem) interface{} {
+			return params.Value().(map[string]string)["event"]
+		}).
+		Result()
+	assert.Equal(t, "created", res)
+
+	isMatched, _ := Match("order-").When(Tmpl("order-${id}"), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match("a.b(c)").When(Tmpl("a.b(${x})"), true).Result()
+	assert.True(t, isMatched)
+}
+
+func TestTmpl_Invalid(t *testing.T) {
+	for _, template := range []string{"order-${id", "order-${}"} {
+		pattern := Tmpl(template)
+		_, _, err := Match("order-1").When(pattern, true).ResultE()
+		assert.IsType(t, &PatternError{}, err)
+		assert.Equal(t, pattern, err.(*PatternError).Pattern)
+	}
+}
@@ -0,0 +1,113 @@
+package match
+
+import (
+	"reflect"
+)
+
+// TreeNode is implemented by the tree types which Node can't read the
+// children of by the field name.
+type TreeNode interface {
+	TreeChildren() []interface{}
+}
+
+type nodePattern struct {
+	value    interface{}
+	children []interface{}
+}
+
+// Node defines the pattern for tree nodes matching the value pattern whose
+// children match the child patterns in order, e.g.
+//
+//	match.Node(match.Fields{"Title": "CEO"}, match.ANYSUBTREE, match.Node(match.Fields{"Title": "CTO"}, match.ANYSUBTREE), match.ANYSUBTREE)
+//
+// matches the org charts where the CTO reports to the CEO. ANYSUBTREE
+// matches any number of child subtrees and binds them as Slice, ANY matches
+// exactly one. The children are read from TreeNode, from the Children field
+// of structs (or pointers to them) or from the "children" key of maps like
+// decoded JSON objects, other values are leaves. The action gets the values
+// bound by the patterns in order.
+func Node(valuePattern interface{}, childPatterns ...interface{}) interface{} {
+	return nodePattern{valuePattern, childPatterns}
+}
+
+func (np nodePattern) formatPattern() string {
+	if len(np.children) == 0 {
+		return "Node(" + FormatPattern(np.value) + ")"
+	}
+
+	return "Node(" + FormatPattern(np.value) + ", " + formatList(np.children) + ")"
+}
+
+func (np nodePattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	valueItems, matched := matchValue(ms, np.value, value)
+	if !matched {
+		return nil, false
+	}
+
+	childItems, matched := matchChildren(ms, np.children, childrenOf(value))
+	if !matched {
+		return nil, false
+	}
+
+	return append(valueItems, childItems...), true
+}
+
+// matchChildren matches the children with the patterns, ANYSUBTREE is
+// backtracked from the shortest span.
+func matchChildren(ms *matchState, patterns []interface{}, children []interface{}) ([]MatchItem, bool) {
+	if len(patterns) == 0 {
+		return nil, len(children) == 0
+	}
+
+	if patterns[0] == ANYSUBTREE {
+		for n := 0; n <= len(children); n++ {
+			rest, matched := tryAlternative(ms, func() ([]MatchItem, bool) {
+				return matchChildren(ms, patterns[1:], children[n:])
+			})
+			if matched {
+				skipped := MatchItem{valueAsSlice: append([]interface{}{}, children[:n]...)}
+				return append([]MatchItem{skipped}, rest...), true
+			}
+		}
+
+		return nil, false
+	}
+
+	if len(children) == 0 {
+		return nil, false
+	}
+
+	items, matched := matchValue(ms, patterns[0], children[0])
+	if !matched {
+		return nil, false
+	}
+
+	rest, matched := matchChildren(ms, patterns[1:], children[1:])
+	if !matched {
+		return nil, false
+	}
+
+	return append(items, rest...), true
+}
+
+func childrenOf(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case TreeNode:
+		return v.TreeChildren()
+	case map[string]interface{}:
+		children, _ := v["children"].([]interface{})
+		return children
+	}
+
+	structValue, ok := structValueOf(value)
+	if !ok {
+		return nil
+	}
+
+	field := structValue.FieldByName("Children")
+	if !field.IsValid() || !field.CanInterface() || (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) {
+		return nil
+	}
+
+	return sliceValueToSliceOfInterfaces(field)
+}
@@ -0,0 +1,63 @@
+package match
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type employee struct {
+	Title    string
+	Children []*employee
+}
+
+type expr struct {
+	op   string
+	args []interface{}
+}
+
+func (e expr) TreeChildren() []interface{} { return e.args }
+
+func TestNode_Structs(t *testing.T) {
+	chart := &employee{"CEO", []*employee{
+		{"CFO", nil},
+		{"CTO", []*employee{{"Engineer", nil}, {"Engineer", nil}}},
+	}}
+
+	_, res := Match(chart).
+		When(Node(Fields{"Title": "CEO"}, ANYSUBTREE, Node(Fields{"Title": "COO"}, ANYSUBTREE), ANYSUBTREE), "has COO").
+		When(Node(Fields{"Title": "CEO"}, ANYSUBTREE, Node(Fields{"Title": "CTO"}, ANYSUBTREE), ANYSUBTREE), func(before, reports, after MatchItem) interface{} {
+			return []int{len(before.Slice()), len(reports.Slice()), len(after.Slice())}
+		}).
+		Result()
+
+	assert.Equal(t, []int{1, 2, 0}, res)
+}
+
+func TestNode_JSON(t *testing.T) {
+	var tree interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "root", "children": [{"name": "a"}, {"name": "b", "children": [{"name": "c"}]}]}`), &tree))
+
+	isMatched, _ := Match(tree).When(Node(map[string]interface{}{"name": "root"}, ANY, Node(ANY, ANY)), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match(tree).When(Node(ANY, ANY), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match(tree).When(Node(ANY, ANYSUBTREE, Node(map[string]interface{}{"name": "a"})), true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestNode_TreeNode(t *testing.T) {
+	// (+ 1 (* 2 x))
+	e := expr{"+", []interface{}{1, expr{"*", []interface{}{2, "x"}}}}
+	isOp := func(op string) interface{} {
+		return func(e expr) bool { return e.op == op }
+	}
+
+	isMatched, _ := Match(e).When(Node(isOp("+"), ANY, Node(isOp("*"), ANYSUBTREE, Node("x"))), true).Result()
+	assert.True(t, isMatched)
+
+	assert.Equal(t, `Node(ANY, ANYSUBTREE, Node("x"))`, FormatPattern(Node(ANY, ANYSUBTREE, Node("x"))))
+}
@@ -0,0 +1,69 @@
+package match
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrUnknownVariant is returned by MatchUnion when no type is registered for
+// the discriminator value.
+var ErrUnknownVariant = errors.New("match: unknown union variant")
+
+type unionKey struct {
+	field string
+	value string
+}
+
+var (
+	unionTypesMu sync.RWMutex
+	unionTypes   = map[unionKey]reflect.Type{}
+)
+
+// RegisterUnionType registers T as the type of JSON objects whose
+// discriminator field has the value, e.g. RegisterUnionType[Click]("type", "click").
+func RegisterUnionType[T any](field string, value string) {
+	unionTypesMu.Lock()
+	defer unionTypesMu.Unlock()
+
+	unionTypes[unionKey{field, value}] = reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// MatchUnion function reads the discriminator field of the JSON object,
+// decodes the object into the type registered for its value by
+// RegisterUnionType and returns the Matcher for the decoded value (not a
+// pointer), so the branches can use StructOf or type-checking func patterns.
+// Discriminators which are JSON numbers or booleans are looked up by their text.
+func MatchUnion(raw []byte, field string) (*Matcher, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+
+	discriminator, ok := object[field]
+	if !ok {
+		return nil, fmt.Errorf("match: union has no discriminator field %q", field)
+	}
+
+	value := string(discriminator)
+	var str string
+	if err := json.Unmarshal(discriminator, &str); err == nil {
+		value = str
+	}
+
+	unionTypesMu.RLock()
+	variantType, ok := unionTypes[unionKey{field, value}]
+	unionTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %q", ErrUnknownVariant, field, value)
+	}
+
+	variant := reflect.New(variantType)
+	if err := json.Unmarshal(raw, variant.Interface()); err != nil {
+		return nil, err
+	}
+
+	return Match(variant.Elem().Interface()), nil
+}
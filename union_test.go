@@ -0,0 +1,55 @@
+package match
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClick struct {
+	X, Y int
+}
+
+type testKeyPress struct {
+	Key string
+}
+
+func TestMatchUnion(t *testing.T) {
+	RegisterUnionType[testClick]("type", "click")
+	RegisterUnionType[testKeyPress]("type", "key")
+	RegisterUnionType[testKeyPress]("kind", "1")
+
+	handle := func(raw string) interface{} {
+		matcher, err := MatchUnion([]byte(raw), "type")
+		assert.NoError(t, err)
+
+		_, res := matcher.
+			When(StructOf[testClick]().Field("X", Lt(0)), "offscreen click").
+			When(func(testClick) {}, "click").
+			When(StructOf[testKeyPress]().Field("Key", "Enter"), "submit").
+			When(ANY, "other").
+			Result()
+		return res
+	}
+
+	assert.Equal(t, "click", handle(`{"type": "click", "x": 10, "y": 20}`))
+	assert.Equal(t, "offscreen click", handle(`{"type": "click", "x": -1, "y": 20}`))
+	assert.Equal(t, "submit", handle(`{"type": "key", "key": "Enter"}`))
+
+	matcher, err := MatchUnion([]byte(`{"kind": 1, "key": "a"}`), "kind")
+	assert.NoError(t, err)
+	_, res := matcher.When(testKeyPress{"a"}, true).Result()
+	assert.Equal(t, true, res)
+}
+
+func TestMatchUnion_Errors(t *testing.T) {
+	_, err := MatchUnion([]byte(`{"type": "scroll"}`), "type")
+	assert.True(t, errors.Is(err, ErrUnknownVariant))
+
+	_, err = MatchUnion([]byte(`{"x": 1}`), "type")
+	assert.Error(t, err)
+
+	_, err = MatchUnion([]byte(`[1]`), "type")
+	assert.Error(t, err)
+}
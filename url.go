@@ -0,0 +1,45 @@
+package match
+
+import (
+	"net/url"
+)
+
+type urlPattern struct {
+	scheme interface{}
+	host   interface{}
+	path   interface{}
+}
+
+// URLOf defines the pattern for *url.URL, url.URL or URL string values with
+// patterns for the scheme, host and path components, e.g.
+// URLOf("https", Glob("*.example.com"), regexp.MustCompile("^/hooks/")).
+func URLOf(scheme interface{}, host interface{}, path interface{}) interface{} {
+	return urlPattern{scheme, host, path}
+}
+
+func (up urlPattern) formatPattern() string {
+	return "URLOf(" + formatList([]interface{}{up.scheme, up.host, up.path}) + ")"
+}
+
+func (up urlPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	var u *url.URL
+	switch v := value.(type) {
+	case *url.URL:
+		u = v
+	case url.URL:
+		u = &v
+	case string:
+		var err error
+		if u, err = url.Parse(v); err != nil {
+			return nil, false
+		}
+	}
+
+	if u == nil {
+		return nil, false
+	}
+
+	return nil, matchValueBool(ms, up.scheme, u.Scheme) &&
+		matchValueBool(ms, up.host, u.Hostname()) &&
+		matchValueBool(ms, up.path, u.Path)
+}
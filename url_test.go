@@ -0,0 +1,35 @@
+package match
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_URLOf(t *testing.T) {
+	_, res := Match("https://api.example.com/hooks/github").
+		When(URLOf("http", ANY, ANY), "insecure").
+		When(URLOf("https", Glob("*.example.com"), regexp.MustCompile("^/hooks/")), "hook").
+		Result()
+
+	assert.Equal(t, "hook", res)
+}
+
+func TestMatch_URLOfParsedURL(t *testing.T) {
+	u, _ := url.Parse("ftp://files.example.org:21/pub")
+	isMatched, _ := Match(u).
+		When(URLOf(OneOf("ftp", "sftp"), "files.example.org", Glob("/pub*")), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatch_URLOfNotURL(t *testing.T) {
+	isMatched, _ := Match(42).
+		When(URLOf(ANY, ANY, ANY), true).
+		Result()
+
+	assert.False(t, isMatched)
+}
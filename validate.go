@@ -0,0 +1,87 @@
+package match
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FieldError describes the field which doesn't match its pattern.
+type FieldError struct {
+	// Path is the dotted path of the field, it's empty when the value itself
+	// isn't a value of the struct pattern.
+	Path    string
+	Value   interface{}
+	Pattern interface{}
+}
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("match: value %s doesn't match %s", truncate(FormatPattern(e.Value)), truncate(FormatPattern(e.Pattern)))
+	}
+
+	return fmt.Sprintf("match: field %s: value %s doesn't match %s", e.Path, truncate(FormatPattern(e.Value)), truncate(FormatPattern(e.Pattern)))
+}
+
+// Validate checks the value against the struct pattern (StructOf or Fields)
+// and returns every field which doesn't match, instead of stopping at the
+// first one. Field patterns which are struct patterns too are validated
+// recursively. Like Result, it panics with *PatternError on invalid
+// patterns, e.g. unknown fields of StructOf.
+func Validate(value interface{}, structPattern interface{}) []FieldError {
+	return validateStruct(&matchState{}, "", value, structPattern)
+}
+
+func validateStruct(ms *matchState, path string, value interface{}, pattern interface{}) []FieldError {
+	var fields []fieldPattern
+	switch p := pattern.(type) {
+	case *StructPattern:
+		structValue, ok := structValueOf(value)
+		if !ok || structValue.Type() != p.structType {
+			return []FieldError{{Path: path, Value: value, Pattern: pattern}}
+		}
+
+		fields = p.fields
+	case Fields:
+		if _, ok := structValueOf(value); !ok {
+			return []FieldError{{Path: path, Value: value, Pattern: pattern}}
+		}
+
+		for name, fp := range p {
+			fields = append(fields, fieldPattern{name, fp})
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	default:
+		if matchValueBool(ms, pattern, value) {
+			return nil
+		}
+
+		return []FieldError{{Path: path, Value: value, Pattern: pattern}}
+	}
+
+	for _, fp := range fields {
+		if isVarPattern(fp.pattern) {
+			ms, fields = scopeFields(ms, fields, false)
+			break
+		}
+	}
+
+	structValue, _ := structValueOf(value)
+
+	var errs []FieldError
+	for _, fp := range fields {
+		fieldPath := fp.name
+		if path != "" {
+			fieldPath = path + "." + fp.name
+		}
+
+		fieldValue, ok := lookupField(ms, pattern, structValue, fp.name)
+		if !ok {
+			errs = append(errs, FieldError{Path: fieldPath, Pattern: fp.pattern})
+			continue
+		}
+
+		errs = append(errs, validateStruct(ms, fieldPath, fieldValue.Interface(), fp.pattern)...)
+	}
+
+	return errs
+}
@@ -0,0 +1,49 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAddress struct {
+	City string
+	Zip  string
+}
+
+type testSignup struct {
+	Name    string
+	Age     int
+	Email   string
+	Address testAddress
+}
+
+func TestValidate(t *testing.T) {
+	pattern := StructOf[testSignup]().
+		Field("Name", func(s string) bool { return s != "" }).
+		Field("Age", Between(18, 130)).
+		Field("Email", regexp.MustCompile("^[^@]+@[^@]+$")).
+		Field("Address", Fields{"City": ANY, "Zip": regexp.MustCompile(`^\d{5}$`)})
+
+	valid := testSignup{"Ann", 30, "ann@example.com", testAddress{"Paris", "75001"}}
+	assert.Empty(t, Validate(valid, pattern))
+
+	invalid := testSignup{"", 12, "ann", testAddress{"Paris", "750"}}
+	errs := Validate(&invalid, pattern)
+
+	var paths []string
+	for _, err := range errs {
+		paths = append(paths, err.Path)
+	}
+	assert.Equal(t, []string{"Name", "Age", "Email", "Address.Zip"}, paths)
+	assert.Equal(t, 12, errs[1].Value)
+	assert.Equal(t, `match: field Address.Zip: value "750" doesn't match /^\d{5}$/`, errs[3].Error())
+}
+
+func TestValidate_WrongType(t *testing.T) {
+	errs := Validate(42, StructOf[testSignup]())
+
+	assert.Equal(t, []FieldError{{Value: 42, Pattern: StructOf[testSignup]()}}, errs)
+	assert.Equal(t, "match: value 42 doesn't match StructOf[match.testSignup]()", errs[0].Error())
+}
@@ -0,0 +1,24 @@
+package match
+
+import "net/url"
+
+// multiValue is the list of values of a url.Values key, see MatchValues.
+type multiValue []string
+
+// MatchValues function returns the Matcher for the query parameters or form
+// values as map[string]interface{}, where a pattern not bound to the whole
+// list matches the first value of the key, like url.Values.Get. EveryElem,
+// SomeElem and slice patterns match all the values, e.g.
+// map[string]interface{}{"page": Between(1, 100), "tag": SomeElem("go")}.
+// Values which are numbers also match number patterns like in MatchCSVRecord.
+func MatchValues(values url.Values) *Matcher {
+	converted := make(map[string]interface{}, len(values))
+	for key, list := range values {
+		converted[key] = multiValue(list)
+	}
+
+	matcher := Match(converted).WithNumericTolerance()
+	matcher.state.numericStrings = true
+
+	return matcher
+}
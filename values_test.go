@@ -0,0 +1,43 @@
+package match
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchValues(t *testing.T) {
+	query, _ := url.ParseQuery("page=2&tag=go&tag=generics&sort=date")
+
+	_, res := MatchValues(query).
+		When(map[string]interface{}{"page": Gt(10)}, "deep").
+		When(map[string]interface{}{"tag": EveryElem(OneOf("go", "rust"))}, "languages").
+		When(map[string]interface{}{"page": Between(1, 10), "tag": SomeElem("generics"), "sort": "date"}, "generics").
+		Result()
+	assert.Equal(t, "generics", res)
+
+	_, res = MatchValues(query).
+		When(map[string]interface{}{"tag": []interface{}{"go", "generics"}}, "exact").
+		Result()
+	assert.Equal(t, "exact", res)
+
+	isMatched, _ := MatchValues(url.Values{"q": {}}).When(map[string]interface{}{"q": ""}, true).Result()
+	assert.False(t, isMatched)
+}
+
+func TestEveryAndSomeElem(t *testing.T) {
+	isMatched, _ := Match([]int{2, 4}).When(EveryElem(func(i int) bool { return i%2 == 0 }), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]int{}).When(EveryElem(1), true).Result()
+	assert.True(t, isMatched)
+
+	isMatched, _ = Match([]int{}).When(SomeElem(1), true).Result()
+	assert.False(t, isMatched)
+
+	isMatched, _ = Match([2]string{"a", "b"}).When(SomeElem("b"), true).Result()
+	assert.True(t, isMatched)
+
+	assert.Equal(t, `SomeElem("b")`, FormatPattern(SomeElem("b")))
+}
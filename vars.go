@@ -0,0 +1,156 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+type varPattern struct {
+	name string
+}
+
+// Var defines the pattern which matches any value and binds it to the name,
+// so DependsOn patterns of other fields can use it. The value is passed to
+// the action too. A name bound twice must have equal values, e.g. Fields{"From":
+// Var("x"), "To": Var("x")} matches structs with equal From and To. Bindings
+// live for the match of the outermost struct pattern (Fields, StructOf) which
+// has Var or DependsOn fields, nested patterns share them.
+func Var(name string) interface{} {
+	return varPattern{name}
+}
+
+func (vp varPattern) formatPattern() string {
+	return "Var(" + strconv.Quote(vp.name) + ")"
+}
+
+func (vp varPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	if ms.vars == nil {
+		return []MatchItem{{value: value}}, true
+	}
+
+	if bound, ok := ms.vars[vp.name]; ok && !reflect.DeepEqual(bound, value) {
+		return nil, false
+	}
+
+	ms.vars[vp.name] = value
+
+	return []MatchItem{{value: value}}, true
+}
+
+// tryAlternative matches an alternative of a pattern, like an item of OneOf
+// or an offset of HEAD, and undoes the Var bindings it made when it fails,
+// so they don't affect the next alternatives.
+func tryAlternative[T any](ms *matchState, alternative func() (T, bool)) (T, bool) {
+	snapshot := ms.snapshotVars()
+	res, matched := alternative()
+	if !matched {
+		ms.restoreVars(snapshot)
+	}
+
+	return res, matched
+}
+
+func (ms *matchState) snapshotVars() map[string]interface{} {
+	if ms.vars == nil {
+		return nil
+	}
+
+	snapshot := make(map[string]interface{}, len(ms.vars))
+	for name, value := range ms.vars {
+		snapshot[name] = value
+	}
+
+	return snapshot
+}
+
+// restoreVars restores the bindings in place, the map is shared by the
+// nested patterns of the scope.
+func (ms *matchState) restoreVars(snapshot map[string]interface{}) {
+	if snapshot == nil {
+		return
+	}
+
+	for name := range ms.vars {
+		if _, ok := snapshot[name]; !ok {
+			delete(ms.vars, name)
+		}
+	}
+	for name, value := range snapshot {
+		ms.vars[name] = value
+	}
+}
+
+type dependsOnPattern[K comparable] struct {
+	name  string
+	cases map[K]interface{}
+}
+
+// DependsOn defines the pattern chosen by the value bound to the name by Var,
+// e.g. Fields{"Type": Var("t"), "Payload": DependsOn("t", map[string]interface{}{
+// "click": clickPattern, "scroll": scrollPattern})}. It doesn't match when the
+// name isn't bound or there is no case for its value. Struct patterns check
+// DependsOn fields after the other ones, so the order of fields doesn't matter.
+func DependsOn[K comparable](name string, cases map[K]interface{}) interface{} {
+	return dependsOnPattern[K]{name, cases}
+}
+
+func (dp dependsOnPattern[K]) formatPattern() string {
+	return fmt.Sprintf("DependsOn(%s, %s)", strconv.Quote(dp.name), formatMap(reflect.ValueOf(dp.cases)))
+}
+
+func (dp dependsOnPattern[K]) dependsOn() {}
+
+func (dp dependsOnPattern[K]) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	bound, ok := ms.vars[dp.name].(K)
+	if !ok {
+		return nil, false
+	}
+
+	pattern, ok := dp.cases[bound]
+	if !ok {
+		return nil, false
+	}
+
+	return matchValue(ms, pattern, value)
+}
+
+// dependentPattern is implemented by DependsOn patterns.
+type dependentPattern interface {
+	dependsOn()
+}
+
+func isVarPattern(pattern interface{}) bool {
+	switch pattern.(type) {
+	case varPattern, dependentPattern:
+		return true
+	}
+
+	return false
+}
+
+// scopeFields returns the state with the scope for Var bindings and the
+// fields in the order to check them: fields of the Fields map by name and
+// DependsOn fields at the end.
+func scopeFields(ms *matchState, fields []fieldPattern, byName bool) (*matchState, []fieldPattern) {
+	ordered := append([]fieldPattern(nil), fields...)
+	if byName {
+		sort.Slice(ordered, func(i, j int) bool { return ordered[i].name < ordered[j].name })
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		_, iDependent := ordered[i].pattern.(dependentPattern)
+		_, jDependent := ordered[j].pattern.(dependentPattern)
+		return !iDependent && jDependent
+	})
+
+	if ms.vars != nil {
+		return ms, ordered
+	}
+
+	scoped := *ms
+	scoped.vars = map[string]interface{}{}
+
+	return &scoped, ordered
+}
@@ -0,0 +1,99 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	Type    string
+	Payload map[string]interface{}
+}
+
+type testTransfer struct {
+	From string
+	To   string
+}
+
+func TestDependsOn(t *testing.T) {
+	pattern := Fields{
+		"Payload": DependsOn("t", map[string]interface{}{
+			"click":  map[string]interface{}{"x": ANY, "y": ANY},
+			"scroll": map[string]interface{}{"delta": Gt(0)},
+		}),
+		"Type": Var("t"),
+	}
+
+	valid := func(event testEvent) bool {
+		isMatched, _ := Match(event).When(pattern, true).Result()
+		return isMatched
+	}
+
+	assert.True(t, valid(testEvent{"click", map[string]interface{}{"x": 1, "y": 2}}))
+	assert.True(t, valid(testEvent{"scroll", map[string]interface{}{"delta": 3}}))
+	assert.False(t, valid(testEvent{"scroll", map[string]interface{}{"x": 1, "y": 2}}))
+	assert.False(t, valid(testEvent{"resize", map[string]interface{}{}}))
+}
+
+func TestVar_Unification(t *testing.T) {
+	pattern := StructOf[testTransfer]().Field("From", Var("account")).Field("To", Var("account"))
+
+	_, res := Match(testTransfer{"a", "a"}).
+		When(pattern, "self").
+		When(ANY, "other").
+		Result()
+	assert.Equal(t, "self", res)
+
+	_, res = Match(testTransfer{"a", "b"}).
+		When(pattern, "self").
+		When(ANY, "other").
+		Result()
+	assert.Equal(t, "other", res)
+}
+
+func TestVar_WithoutScope(t *testing.T) {
+	_, res := Match(42).When(Var("x"), func(x MatchItem) interface{} { return x.Value() }).Result()
+	assert.Equal(t, 42, res)
+
+	isMatched, _ := Match(42).When(DependsOn("x", map[int]interface{}{42: ANY}), true).Result()
+	assert.False(t, isMatched)
+
+	assert.Equal(t, `DependsOn("t", {"a": 1})`, FormatPattern(DependsOn("t", map[string]interface{}{"a": 1})))
+}
+
+func TestValidate_DependsOn(t *testing.T) {
+	pattern := Fields{
+		"Type":    Var("t"),
+		"Payload": DependsOn("t", map[string]interface{}{"scroll": map[string]interface{}{"delta": Gt(0)}}),
+	}
+
+	assert.Empty(t, Validate(testEvent{"scroll", map[string]interface{}{"delta": 1}}, pattern))
+	assert.Len(t, Validate(testEvent{"scroll", map[string]interface{}{"delta": -1}}, pattern), 1)
+}
+
+type testPair struct {
+	A []string
+	B string
+}
+
+func TestVar_FailedAlternativesDontBind(t *testing.T) {
+	matches := func(pattern Fields, value testPair) bool {
+		isMatched, _ := Match(value).When(pattern, true).Result()
+		return isMatched
+	}
+
+	oneOf := Fields{
+		"A": OneOf([]interface{}{Var("x"), "z"}, []interface{}{"p", Var("x")}),
+		"B": Var("x"),
+	}
+	assert.True(t, matches(oneOf, testPair{[]string{"p", "q"}, "q"}))
+	assert.False(t, matches(oneOf, testPair{[]string{"p", "q"}, "p"}))
+
+	head := Fields{
+		"A": []interface{}{HEAD, Var("x"), "end"},
+		"B": Var("x"),
+	}
+	assert.True(t, matches(head, testPair{[]string{"s", "t", "end"}, "t"}))
+	assert.False(t, matches(head, testPair{[]string{"s", "t", "end"}, "s"}))
+}
@@ -0,0 +1,160 @@
+package match
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XMLNode is a generic XML element decoded by MatchXML.
+type XMLNode struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Children []*XMLNode
+}
+
+// MatchXML function decodes the XML document into an *XMLNode tree and
+// returns the Matcher for its root element. Use XMLPath patterns to match
+// nested elements and attributes.
+func MatchXML(raw []byte) (*Matcher, error) {
+	root, err := decodeXML(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return Match(root), nil
+}
+
+type xmlPathPattern struct {
+	path    []string
+	pattern interface{}
+}
+
+// XMLPath defines the pattern for *XMLNode values which have at least one
+// element matching the slash separated path from the root element, like
+// "feed/entry/title". A "*" segment matches any element, "**" any number of
+// nested elements, so a trailing "**" matches all the descendants, and the
+// last segment can be "@name" to select an attribute.
+// Elements are matched by their text first and by the *XMLNode itself then,
+// so string, regexp and Fields patterns can be used.
+func XMLPath(path string, pattern interface{}) interface{} {
+	return xmlPathPattern{strings.Split(strings.Trim(path, "/"), "/"), pattern}
+}
+
+func (xp xmlPathPattern) formatPattern() string {
+	return fmt.Sprintf("XMLPath(%q, %s)", strings.Join(xp.path, "/"), FormatPattern(xp.pattern))
+}
+
+func (xp xmlPathPattern) matchValue(ms *matchState, value interface{}) ([]MatchItem, bool) {
+	node, ok := value.(*XMLNode)
+	if !ok {
+		return nil, false
+	}
+
+	return nil, xp.matchNode(ms, node, xp.path)
+}
+
+func (xp xmlPathPattern) matchNode(ms *matchState, node *XMLNode, path []string) bool {
+	segment := path[0]
+	if segment == "**" {
+		// a trailing "**" matches the element and all its descendants
+		if len(path) == 1 && xp.matchElement(ms, node) {
+			return true
+		}
+
+		if len(path) > 1 && xp.matchNode(ms, node, path[1:]) {
+			return true
+		}
+
+		for _, child := range node.Children {
+			if xp.matchNode(ms, child, path) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if strings.HasPrefix(segment, "@") {
+		attr, ok := node.Attrs[segment[1:]]
+		return ok && len(path) == 1 && matchValueBool(ms, xp.pattern, attr)
+	}
+
+	if segment != "*" && segment != node.Name {
+		return false
+	}
+
+	if len(path) == 1 {
+		return xp.matchElement(ms, node)
+	}
+
+	if strings.HasPrefix(path[1], "@") {
+		return xp.matchNode(ms, node, path[1:])
+	}
+
+	for _, child := range node.Children {
+		if xp.matchNode(ms, child, path[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (xp xmlPathPattern) matchElement(ms *matchState, node *XMLNode) bool {
+	return matchValueBool(ms, xp.pattern, node.Text) || matchValueBool(ms, xp.pattern, node)
+}
+
+func decodeXML(raw []byte) (*XMLNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+
+	var root *XMLNode
+	var stack []*XMLNode
+	var text []*strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF && root != nil && len(stack) == 0 {
+			return root, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, fmt.Errorf("match: XML element %s after the root element", t.Name.Local)
+			}
+
+			node := &XMLNode{Name: t.Name.Local, Attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else if root == nil {
+				root = node
+			}
+
+			stack = append(stack, node)
+			text = append(text, &strings.Builder{})
+		case xml.CharData:
+			if len(text) > 0 {
+				text[len(text)-1].Write(t)
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("match: XML text outside the root element")
+			}
+		case xml.EndElement:
+			stack[len(stack)-1].Text = strings.TrimSpace(text[len(text)-1].String())
+			stack = stack[:len(stack)-1]
+			text = text[:len(text)-1]
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package match
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const feedXML = `<?xml version="1.0"?>
+<feed lang="en">
+	<entry id="1"><title>Go 1.22 released</title></entry>
+	<entry id="2"><meta><author>gopher</author></meta></entry>
+</feed>`
+
+func TestMatchXML_Path(t *testing.T) {
+	mr, err := MatchXML([]byte(feedXML))
+	assert.NoError(t, err)
+
+	_, res := mr.
+		When(XMLPath("feed/entry/title", "Rust"), "rust").
+		When(XMLPath("feed/entry/title", regexp.MustCompile(`^Go \d`)), "go").
+		Result()
+
+	assert.Equal(t, "go", res)
+}
+
+func TestMatchXML_Wildcards(t *testing.T) {
+	mr, _ := MatchXML([]byte(feedXML))
+
+	isMatched, _ := mr.
+		When(XMLPath("feed/**/author", "gopher"), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatchXML_Attributes(t *testing.T) {
+	mr, _ := MatchXML([]byte(feedXML))
+
+	_, res := mr.
+		When([]interface{}{XMLPath("feed/@lang", "de")}, "slice").
+		When(XMLPath("feed/*/@id", "3"), "third").
+		When(XMLPath("feed/@lang", OneOf("en", "fr")), "lang").
+		Result()
+
+	assert.Equal(t, "lang", res)
+}
+
+func TestMatchXML_Node(t *testing.T) {
+	mr, _ := MatchXML([]byte(feedXML))
+
+	isMatched, _ := mr.
+		When(XMLPath("feed/entry", Fields{"Attrs": map[string]interface{}{"id": "2"}}), true).
+		Result()
+
+	assert.True(t, isMatched)
+}
+
+func TestMatchXML_TrailingWildcard(t *testing.T) {
+	mr, _ := MatchXML([]byte(feedXML))
+
+	_, res := mr.
+		When(XMLPath("feed/entry/**", "Rust"), "rust").
+		When(XMLPath("feed/entry/**", "gopher"), "gopher").
+		Result()
+
+	assert.Equal(t, "gopher", res)
+}
+
+func TestMatchXML_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"<feed><entry></feed>",
+		"<feed></feed><feed></feed>",
+		"<feed></feed>trailing",
+		"",
+	} {
+		_, err := MatchXML([]byte(raw))
+		assert.Error(t, err, raw)
+	}
+
+	_, err := MatchXML([]byte("<feed></feed>\n<!-- comment -->\n"))
+	assert.NoError(t, err)
+}